@@ -0,0 +1,156 @@
+package clickableai
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// adminTopLimit is the number of topics and trails on the admin dashboard
+const adminTopLimit = 10
+
+// AdminData is what the admin dashboard shows
+type AdminData struct {
+	Metrics        MetricsSnapshot `json:"metrics"`
+	Pages          int             `json:"pages"`     // the number of cached pages
+	Kept           int             `json:"kept"`      // the number of cached pages that are bookmarked
+	MaxPages       int             `json:"max_pages"` // 0 for no limit
+	InFlight       int             `json:"in_flight"` // backend requests that hold a slot of the limiter
+	Waiting        int             `json:"waiting"`   // backend requests that wait for a slot
+	MaxConcurrent  int             `json:"max_concurrent,omitempty"`
+	Generator      string          `json:"generator"`
+	DefaultBackend string          `json:"default_backend,omitempty"` // the backend that is tried first, with several backends
+	Health         []BackendHealth `json:"health,omitempty"`          // the health of each backend, with several backends
+}
+
+// adminData collects the data for the admin dashboard
+func (s *Server) adminData() AdminData {
+	data := AdminData{
+		Metrics:   s.Metrics.Snapshot(adminTopLimit),
+		Pages:     s.Cache.Len(),
+		Kept:      s.Cache.Kept(),
+		MaxPages:  s.Cache.MaxPages,
+		Generator: s.Generator.Name(),
+	}
+	if s.Limiter != nil {
+		data.InFlight = s.Limiter.InFlight()
+		data.Waiting = s.Limiter.Waiting()
+		data.MaxConcurrent = s.Limiter.Max()
+	} else {
+		data.InFlight = int(data.Metrics.Generating)
+	}
+	if s.Router != nil {
+		data.Health = s.Router.Health()
+		for i := range data.Health {
+			data.Health[i].AverageLatency = data.Health[i].AverageLatency.Round(time.Microsecond)
+		}
+		if len(data.Health) > 0 {
+			data.DefaultBackend = data.Health[0].Name
+		}
+	}
+	return data
+}
+
+// findRouter returns the Router of a Generator, that may be wrapped by other Generators,
+// or nil if there is none
+func findRouter(g Generator) *Router {
+	for {
+		switch wrapper := g.(type) {
+		case *Router:
+			return wrapper
+		case *Limiter:
+			g = wrapper.Generator
+		case *Metered:
+			g = wrapper.Generator
+		case *Recorder:
+			g = wrapper.Generator
+		default:
+			return nil
+		}
+	}
+}
+
+// adminHandler shows the admin dashboard to admins
+func (s *Server) adminHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	if !s.checkAdmin(w, r) {
+		return
+	}
+	data := s.adminData()
+	s.render(w, r, s.adminTmpl, PageData{Admin: &data, EditToken: s.Auth == nil})
+}
+
+// adminAPIHandler returns the data of the admin dashboard as JSON, to admins
+func (s *Server) adminAPIHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	if !s.checkAdmin(w, r) {
+		return
+	}
+	writeJSON(w, s.adminData())
+}
+
+// purgeHandler removes pages from the cache. With "all" set, every page is removed. Otherwise, the
+// page given by the usual "keywords", "lang" and "model" fields is removed, together with the pages
+// below it if "below" is set. Returns the number of removed pages.
+func (s *Server) purgeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Error: Use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	r.ParseForm()
+	if !s.checkAdmin(w, r) {
+		return
+	}
+	var match func(Page) bool
+	if r.FormValue("all") != "" {
+		match = func(Page) bool { return true }
+	} else {
+		id := s.pageID(r)
+		if len(id.Trail) == 0 {
+			http.Error(w, "Error: No keywords given", http.StatusBadRequest)
+			return
+		}
+		below := r.FormValue("below") != ""
+		match = func(page Page) bool {
+			if page.Lang != id.Lang || page.Model != id.Model || len(page.Trail) < len(id.Trail) {
+				return false
+			}
+			if !below && len(page.Trail) != len(id.Trail) {
+				return false
+			}
+			for i, keyword := range id.Trail {
+				if page.Trail[i] != keyword {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	purged := s.Cache.Purge(match)
+	log.Printf("Purged %d pages from the cache\n", purged)
+	writeJSON(w, map[string]int{"purged": purged})
+}
+
+// defaultBackendHandler makes the backend in the "backend" field the one that is tried first
+func (s *Server) defaultBackendHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Error: Use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	r.ParseForm()
+	if !s.checkAdmin(w, r) {
+		return
+	}
+	if s.Router == nil {
+		http.Error(w, "Error: There is only one backend", http.StatusNotFound)
+		return
+	}
+	name := strings.TrimSpace(r.FormValue("backend"))
+	if err := s.Router.SetDefault(name); err != nil {
+		http.Error(w, "Error: Unknown backend", http.StatusNotFound)
+		return
+	}
+	log.Printf("The default backend is now %s\n", name)
+	writeJSON(w, map[string]string{"backend": name})
+}
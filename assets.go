@@ -0,0 +1,38 @@
+package clickableai
+
+import (
+	"embed"
+	"io/fs"
+	"strings"
+)
+
+// Assets holds the files that are shared by all clickableai commands:
+// the HTML template, JavaScript, images, robots.txt and the default topics
+//
+//go:embed assets
+var Assets embed.FS
+
+// Asset returns the contents of the given file in the assets directory
+func Asset(name string) []byte {
+	data, err := fs.ReadFile(Assets, "assets/"+name)
+	if err != nil {
+		panic("clickableai: missing embedded asset: " + name)
+	}
+	return data
+}
+
+// DefaultTopics returns the initial topics from the embedded topics.conf
+func DefaultTopics() []string {
+	return SplitTopics(string(Asset("topics.conf")))
+}
+
+// SplitTopics splits a comma-separated list of topics and trims the whitespace around each topic
+func SplitTopics(s string) []string {
+	var topics []string
+	for _, topic := range strings.Split(s, ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
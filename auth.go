@@ -0,0 +1,443 @@
+package clickableai
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// The roles of users, in increasing order of permissions. Viewers can read, generate and bookmark
+// pages and give feedback. Editors can also regenerate and edit pages and pin versions. Admins can
+// also see the admin pages and pull models.
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// Roles are all the roles, in increasing order of permissions
+var Roles = []string{RoleViewer, RoleEditor, RoleAdmin}
+
+const (
+	// passwordCost is the bcrypt cost of new password hashes
+	passwordCost = 12
+	// credentialTTL is how long verified credentials are remembered, so that HTTP Basic
+	// does not hash the password on every request
+	credentialTTL = 10 * time.Minute
+	// maxCredentials is the maximum number of verified credentials that are remembered
+	maxCredentials = 1000
+)
+
+// User is an authenticated user
+type User struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// Can checks if the user has at least the given role. A nil user has no roles.
+func (u *User) Can(role string) bool {
+	return u != nil && roleLevel(u.Role) >= roleLevel(role) && roleLevel(role) >= 0
+}
+
+// roleLevel returns the position of a role in Roles, or -1 if it is unknown
+func roleLevel(role string) int {
+	for i, r := range Roles {
+		if r == role {
+			return i
+		}
+	}
+	return -1
+}
+
+// Authenticator finds the user of a request. Identity providers like OIDC can be added by
+// implementing it, for instance by checking a session cookie in Authenticate and redirecting
+// to the provider in Challenge.
+type Authenticator interface {
+	// Authenticate returns the user of the request, or nil if the request has no user.
+	// An error is returned if the request has invalid credentials.
+	Authenticate(r *http.Request) (*User, error)
+	// Challenge responds to a request that needs a user, by asking for credentials
+	Challenge(w http.ResponseWriter, r *http.Request)
+}
+
+// userContextKey is the context key for the user of a request
+type userContextKey struct{}
+
+// WithUser returns a copy of the context with the given user
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFrom returns the user of the context, or nil if there is none
+func UserFrom(ctx context.Context) *User {
+	user, _ := ctx.Value(userContextKey{}).(*User)
+	return user
+}
+
+// HashPassword returns a salted bcrypt hash of the password, for the users file
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword checks if the password matches a hash from HashPassword
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// userEntry is a user in the users file
+type userEntry struct {
+	Role string
+	Hash string
+}
+
+// Users is a concurrency-safe set of local users, with roles and password hashes.
+// The users file has one "name:role:hash" line per user, where empty lines and lines
+// starting with # are ignored.
+type Users struct {
+	mu    sync.RWMutex
+	users map[string]userEntry
+}
+
+// NewUsers creates a new and empty set of users
+func NewUsers() *Users {
+	return &Users{users: make(map[string]userEntry)}
+}
+
+// LoadUsers reads the users file. A missing file gives an empty set of users.
+func LoadUsers(filename string) (*Users, error) {
+	users := NewUsers()
+	file, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return users, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected name:role:hash", filename, lineNumber)
+		}
+		if err := users.Set(fields[0], fields[1], fields[2]); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", filename, lineNumber, err)
+		}
+	}
+	return users, scanner.Err()
+}
+
+// Save writes the users file, sorted by name, readable only by the owner
+func (us *Users) Save(filename string) error {
+	us.mu.RLock()
+	names := make([]string, 0, len(us.users))
+	for name := range us.users {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	sb.WriteString("# clickableai users, one name:role:hash per line\n")
+	for _, name := range names {
+		entry := us.users[name]
+		sb.WriteString(name + ":" + entry.Role + ":" + entry.Hash + "\n")
+	}
+	us.mu.RUnlock()
+	return os.WriteFile(filename, []byte(sb.String()), 0o600)
+}
+
+// Set adds or updates a user, with a hash from HashPassword. An empty hash gives a user that
+// can not log in with a password, which is useful for giving roles to users of a reverse proxy.
+func (us *Users) Set(name, role, hash string) error {
+	if name == "" || strings.ContainsAny(name, ":\n") {
+		return fmt.Errorf("invalid user name %q", name)
+	}
+	if roleLevel(role) < 0 {
+		return fmt.Errorf("unknown role %q (the roles are %s)", role, strings.Join(Roles, ", "))
+	}
+	if hash != "" {
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return fmt.Errorf("the password hash of %s is not a bcrypt hash: %v", name, err)
+		}
+	}
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	us.users[name] = userEntry{Role: role, Hash: hash}
+	return nil
+}
+
+// Delete removes a user, and returns false if there was no such user
+func (us *Users) Delete(name string) bool {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+	_, ok := us.users[name]
+	delete(us.users, name)
+	return ok
+}
+
+// Len returns the number of users
+func (us *Users) Len() int {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+	return len(us.users)
+}
+
+// Get returns the user with the given name
+func (us *Users) Get(name string) (*User, bool) {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+	entry, ok := us.users[name]
+	if !ok {
+		return nil, false
+	}
+	return &User{Name: name, Role: entry.Role}, true
+}
+
+// hash returns the password hash of a user
+func (us *Users) hash(name string) string {
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+	return us.users[name].Hash
+}
+
+// BasicAuth authenticates local users with HTTP Basic
+type BasicAuth struct {
+	Users *Users
+	Realm string
+
+	mu       sync.Mutex
+	verified map[[sha256.Size]byte]time.Time // hashes of verified credentials, with when they expire
+}
+
+// NewBasicAuth creates a BasicAuth for the given users
+func NewBasicAuth(users *Users) *BasicAuth {
+	return &BasicAuth{Users: users, Realm: "clickableai", verified: make(map[[sha256.Size]byte]time.Time)}
+}
+
+// Authenticate returns the user of the Authorization header, or nil if there is no such header
+func (ba *BasicAuth) Authenticate(r *http.Request) (*User, error) {
+	name, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+	user, found := ba.Users.Get(name)
+	hash := ba.Users.hash(name)
+	if !found || hash == "" {
+		return nil, errors.New("invalid user name or password")
+	}
+	key := sha256.Sum256([]byte(name + "\x00" + password + "\x00" + hash))
+	now := time.Now()
+	ba.mu.Lock()
+	expires, seen := ba.verified[key]
+	ba.mu.Unlock()
+	if seen && now.Before(expires) {
+		return user, nil
+	}
+	if !CheckPassword(hash, password) {
+		return nil, errors.New("invalid user name or password")
+	}
+	ba.mu.Lock()
+	if len(ba.verified) >= maxCredentials {
+		clear(ba.verified)
+	}
+	ba.verified[key] = now.Add(credentialTTL)
+	ba.mu.Unlock()
+	return user, nil
+}
+
+// Challenge asks the browser for a user name and a password
+func (ba *BasicAuth) Challenge(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="`+ba.Realm+`", charset="UTF-8"`)
+	http.Error(w, "Error: Log in to use this server", http.StatusUnauthorized)
+}
+
+// ProxyAuth trusts a reverse proxy that authenticates users and gives the user name in a header.
+// The role is taken from the users file if the user is there, then from the role header if one is
+// configured, and is DefaultRole otherwise. Headers from other addresses than the trusted proxies
+// are ignored.
+type ProxyAuth struct {
+	UserHeader  string
+	RoleHeader  string
+	DefaultRole string
+	Users       *Users         // optional roles of the users
+	Proxies     []netip.Prefix // the addresses of the trusted proxies
+}
+
+// ParseProxies parses a comma-separated list of addresses and CIDR ranges
+func ParseProxies(s string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, field := range SplitTopics(s) {
+		if strings.Contains(field, "/") {
+			prefix, err := netip.ParsePrefix(field)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(field)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// trusted checks if the request comes directly from one of the trusted proxies
+func (pa *ProxyAuth) trusted(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range pa.Proxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate returns the user in the user header, or nil if there is none
+func (pa *ProxyAuth) Authenticate(r *http.Request) (*User, error) {
+	name := strings.TrimSpace(r.Header.Get(pa.UserHeader))
+	if name == "" {
+		return nil, nil
+	}
+	if !pa.trusted(r) {
+		return nil, fmt.Errorf("the %s header is not from a trusted proxy", pa.UserHeader)
+	}
+	if pa.Users != nil {
+		if user, ok := pa.Users.Get(name); ok {
+			return user, nil
+		}
+	}
+	role := pa.DefaultRole
+	if pa.RoleHeader != "" {
+		if headerRole := strings.ToLower(strings.TrimSpace(r.Header.Get(pa.RoleHeader))); roleLevel(headerRole) >= 0 {
+			role = headerRole
+		}
+	}
+	return &User{Name: name, Role: role}, nil
+}
+
+// Challenge responds that the proxy did not give a user, since the proxy does the logging in
+func (pa *ProxyAuth) Challenge(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "Error: No user was given by the reverse proxy", http.StatusUnauthorized)
+}
+
+// NewAuthenticator creates the authenticator for the configuration, or returns nil if
+// authentication is off
+func (c *Config) NewAuthenticator() (Authenticator, error) {
+	if c.Auth == "off" {
+		return nil, nil
+	}
+	var users *Users
+	if c.UsersFile != "" {
+		var err error
+		if users, err = LoadUsers(c.UsersFile); err != nil {
+			return nil, err
+		}
+	}
+	switch c.Auth {
+	case "basic":
+		if users == nil || users.Len() == 0 {
+			return nil, errors.New("auth: there are no users, add one with the users command")
+		}
+		return NewBasicAuth(users), nil
+	case "proxy":
+		proxies, err := ParseProxies(c.AuthProxies)
+		if err != nil {
+			return nil, fmt.Errorf("auth-proxies: %v", err)
+		}
+		return &ProxyAuth{
+			UserHeader:  c.AuthUserHeader,
+			RoleHeader:  c.AuthRoleHeader,
+			DefaultRole: c.AuthDefaultRole,
+			Users:       users,
+			Proxies:     proxies,
+		}, nil
+	}
+	return nil, fmt.Errorf("auth: %q must be off, basic or proxy", c.Auth)
+}
+
+// authenticate is a middleware that finds the user of every request, when authentication is on.
+// Requests without a user get the anonymous role, or are asked to log in if there is none.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	if s.Auth == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := s.Auth.Authenticate(r)
+		if err != nil {
+			log.Println("Error:", err)
+			s.Auth.Challenge(w, r)
+			return
+		}
+		if user == nil && s.AnonymousRole != "" {
+			user = &User{Role: s.AnonymousRole}
+		}
+		if user == nil && r.URL.Path != "/robots.txt" {
+			s.Auth.Challenge(w, r)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+	})
+}
+
+// userName returns the name of the user of the request, or an empty string if there is none
+func userName(r *http.Request) string {
+	if user := UserFrom(r.Context()); user != nil {
+		return user.Name
+	}
+	return ""
+}
+
+// checkRole responds with an error and returns false if the user of the request does not have
+// the given role. Everyone has every role when authentication is off.
+func (s *Server) checkRole(w http.ResponseWriter, r *http.Request, role string) bool {
+	if s.Auth == nil {
+		return true
+	}
+	user := UserFrom(r.Context())
+	if user.Can(role) {
+		return true
+	}
+	if user == nil || user.Name == "" {
+		s.Auth.Challenge(w, r)
+		return false
+	}
+	http.Error(w, "Error: This needs the "+role+" role", http.StatusForbidden)
+	return false
+}
+
+// meHandler returns the user of the request as JSON, or null if there is none
+func (s *Server) meHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, UserFrom(r.Context()))
+}
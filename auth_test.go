@@ -0,0 +1,50 @@
+package clickableai
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPasswordHash(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$2a$") {
+		t.Errorf("%q is not a bcrypt hash", hash)
+	}
+	if !CheckPassword(hash, "correct horse") || CheckPassword(hash, "battery staple") {
+		t.Error("the password was not checked correctly")
+	}
+	users := NewUsers()
+	if err := users.Set("alice", RoleAdmin, hash); err != nil {
+		t.Error(err)
+	}
+	if err := users.Set("bob", RoleViewer, "pbkdf2-sha256$210000$c2FsdA$aGFzaA"); err == nil {
+		t.Error("a hash that is not a bcrypt hash was accepted")
+	}
+}
+
+func TestProxyAuthRoleHeader(t *testing.T) {
+	c := DefaultConfig()
+	c.Auth = "proxy"
+	auth, err := c.NewAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "127.0.0.1:1234"
+	r.Header.Set("X-Forwarded-User", "mallory")
+	r.Header.Set("X-Forwarded-Role", RoleAdmin)
+	if user, err := auth.Authenticate(r); err != nil || user.Role != RoleViewer {
+		t.Errorf("the role header was trusted by default: %+v, %v", user, err)
+	}
+	c.AuthRoleHeader = "X-Forwarded-Role"
+	if auth, err = c.NewAuthenticator(); err != nil {
+		t.Fatal(err)
+	}
+	if user, err := auth.Authenticate(r); err != nil || user.Role != RoleAdmin {
+		t.Errorf("the configured role header was not used: %+v, %v", user, err)
+	}
+}
@@ -0,0 +1,304 @@
+package clickableai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xyproto/env/v2"
+	"github.com/xyproto/ollamaclient/v2"
+	"github.com/xyproto/simpleflash"
+)
+
+const (
+	// DefaultGeminiModel is the Gemini model that is used for generating text
+	DefaultGeminiModel = "gemini-1.5-flash"
+	// DefaultGeminiMultiModalModel is the Gemini model that is used when data is attached
+	DefaultGeminiMultiModalModel = "gemini-1.0-pro-vision"
+)
+
+// BackendNames lists the backends that can be created with NewBackend
+var BackendNames = []string{"gemini", "ollama", "openai", "fake"}
+
+// Gemini is a Generator that uses Gemini through simpleflash
+type Gemini struct {
+	SF *simpleflash.SimpleFlash
+}
+
+// NewGemini creates a new Gemini Generator, given an initialized SimpleFlash struct
+func NewGemini(sf *simpleflash.SimpleFlash) *Gemini {
+	return &Gemini{SF: sf}
+}
+
+// Name returns the name of this backend
+func (g *Gemini) Name() string {
+	return "gemini"
+}
+
+// Generate sends the prompt to Gemini and returns the generated text
+func (g *Gemini) Generate(ctx context.Context, req Request) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	temperature := req.Temperature
+	if len(req.Images) > 0 {
+		// Gemini is given one image, which makes it use the multimodal model
+		data, mimeType := req.Images[0].Base64(), req.Images[0].MIMEType
+		return g.SF.QueryGemini(req.Prompt, &temperature, &data, &mimeType)
+	}
+	return g.SF.QueryGemini(req.Prompt, &temperature, nil, nil)
+}
+
+// Ollama is a Generator that uses a local or remote Ollama server
+type Ollama struct {
+	Config      *ollamaclient.Config
+	VisionModel string // the model that is used for requests with images, if no model is given
+
+	pullOnce       sync.Once
+	pullErr        error
+	visionPullOnce sync.Once
+	visionPullErr  error
+	noTools        sync.Map // models that do not support tool calling
+}
+
+// NewOllama creates a new Ollama Generator, given an ollamaclient configuration.
+// The model is pulled the first time it is used, if needed.
+func NewOllama(oc *ollamaclient.Config) *Ollama {
+	return &Ollama{Config: oc}
+}
+
+// Name returns the name of this backend
+func (o *Ollama) Name() string {
+	return "ollama"
+}
+
+// config returns a copy of the ollamaclient configuration, adjusted for the given request
+func (o *Ollama) config(req Request) *ollamaclient.Config {
+	oc := *o.Config
+	if req.Model != "" {
+		oc.ModelName = req.Model
+	} else if len(req.Images) > 0 && o.VisionModel != "" {
+		oc.ModelName = o.VisionModel
+	}
+	if req.Temperature > 0 {
+		oc.SeedOrNegative = -1
+		oc.TemperatureIfNegativeSeed = req.Temperature
+	}
+	return &oc
+}
+
+// pullIfNeeded pulls the default model once, if it is not already present
+func (o *Ollama) pullIfNeeded() error {
+	o.pullOnce.Do(func() {
+		o.pullErr = o.Config.PullIfNeeded()
+	})
+	if o.pullErr != nil {
+		return fmt.Errorf("could not pull %s: %v", o.Config.ModelName, o.pullErr)
+	}
+	return nil
+}
+
+// pullVisionIfNeeded pulls the vision model once, if it is configured and not already present.
+// Models that are given by requests are never pulled.
+func (o *Ollama) pullVisionIfNeeded() error {
+	if o.VisionModel == "" {
+		return nil
+	}
+	o.visionPullOnce.Do(func() {
+		oc := *o.Config
+		oc.ModelName = o.VisionModel
+		o.visionPullErr = oc.PullIfNeeded()
+	})
+	if o.visionPullErr != nil {
+		return fmt.Errorf("could not pull %s: %v", o.VisionModel, o.visionPullErr)
+	}
+	return nil
+}
+
+// Generate sends the prompt to Ollama and returns the generated text
+func (o *Ollama) Generate(ctx context.Context, req Request) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if err := o.pullIfNeeded(); err != nil {
+		return "", err
+	}
+	oc := o.config(req)
+	if len(req.Images) > 0 {
+		if req.Model == "" {
+			if err := o.pullVisionIfNeeded(); err != nil {
+				return "", err
+			}
+		}
+		promptAndImages := []string{req.Prompt}
+		for _, img := range req.Images {
+			promptAndImages = append(promptAndImages, img.Base64())
+		}
+		return oc.GetOutput(promptAndImages...)
+	}
+	if req.Topics {
+		if _, noTools := o.noTools.Load(oc.ModelName); !noTools {
+			output, err := suggestTopics(ctx, oc, req.Prompt)
+			switch {
+			case errors.Is(err, errNoToolSupport):
+				log.Printf("%s does not support tools, asking for topics as text instead\n", oc.ModelName)
+				o.noTools.Store(oc.ModelName, true)
+			case errors.Is(err, errNoToolTopics):
+				log.Printf("%s called suggest_topics without topics, asking for topics as text instead\n", oc.ModelName)
+			default:
+				return output, err
+			}
+		}
+	}
+	return oc.GetOutput(req.Prompt)
+}
+
+// GenerateStream sends the prompt to Ollama and calls the callback for every received chunk
+func (o *Ollama) GenerateStream(ctx context.Context, req Request, callback func(chunk string)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := o.pullIfNeeded(); err != nil {
+		return err
+	}
+	return o.config(req).StreamOutput(func(chunk string, done bool) {
+		if !done && chunk != "" {
+			callback(chunk)
+		}
+	}, req.Prompt)
+}
+
+// personaModes are the valid values of Config.Persona
+var personaModes = []string{"auto", "always", "off"}
+
+// ollamaConfig returns an ollamaclient configuration for the configured Ollama server and model
+func (c *Config) ollamaConfig() *ollamaclient.Config {
+	oc := ollamaclient.New(c.OllamaModel)
+	oc.ServerAddr = c.OllamaHost
+	oc.ModelName = c.OllamaModel
+	return oc
+}
+
+// personaModel returns the name of the persona model, or an empty string if it is not used.
+// The persona model is only looked up, and (re)created if needed, the first time. If it can
+// not be created, the configured model is used instead.
+func (c *Config) personaModel(oc *ollamaclient.Config) string {
+	if c.persona != nil {
+		return *c.persona
+	}
+	var name string
+	if c.Persona != "off" && c.FixturesMode != "replay" {
+		var err error
+		if name, err = EnsurePersona(oc, c.OllamaModel, c.MainPrompt, c.DiagramPrompt, c.Persona == "always"); err != nil {
+			log.Println("Error:", err)
+			name = ""
+		}
+	}
+	c.persona = &name
+	return name
+}
+
+// WrapFixtures wraps the given Generator according to the fixture mode, which can be
+// "record" (save request and response pairs to dir), "replay" (serve them from dir) or
+// empty (return the Generator as it is)
+func WrapFixtures(g Generator, dir, mode string) (Generator, error) {
+	switch strings.ToLower(mode) {
+	case "":
+		return g, nil
+	case "record":
+		return NewRecorder(g, dir), nil
+	case "replay":
+		return NewReplayer(dir), nil
+	}
+	return nil, fmt.Errorf("unknown fixture mode: %q", mode)
+}
+
+// NewBackend creates a Generator for the given backend name
+func (c *Config) NewBackend(name string) (Generator, error) {
+	switch strings.TrimSpace(name) {
+	case "gemini":
+		if c.ProjectID == "" {
+			return nil, errors.New("no Google Cloud project ID is configured")
+		}
+		sf, err := simpleflash.New(c.GeminiModel, c.GeminiMultiModalModel, c.ProjectLocation, c.ProjectID, true)
+		if err != nil {
+			return nil, err
+		}
+		sf.ModelName = c.GeminiModel
+		sf.MultiModalModelName = c.GeminiMultiModalModel
+		return NewGemini(sf), nil
+	case "ollama":
+		oc := c.ollamaConfig()
+		oc.Verbose = env.Bool("OLLAMA_VERBOSE")
+		o := NewOllama(oc)
+		o.VisionModel = c.OllamaVisionModel
+		return o, nil
+	case "openai":
+		return NewOpenAI(c.OpenAIBaseURL, c.OpenAIModel), nil
+	case "fake":
+		return NewFake(c.FakeSeed), nil
+	}
+	return nil, fmt.Errorf("unknown backend: %q (available backends: %s)", name, strings.Join(BackendNames, ", "))
+}
+
+// NewGenerator creates a Generator for the configured backends.
+// If more than one backend is configured, they are wrapped in a Router.
+// The Generator is wrapped for recording or replaying fixtures, if configured.
+func (c *Config) NewGenerator() (Generator, error) {
+	if c.FixturesMode == "replay" {
+		// All responses are served from the fixture files
+		return NewReplayer(c.FixturesDir), nil
+	}
+	names := SplitTopics(c.Backend)
+	weights := SplitTopics(c.BackendWeights)
+	var g Generator
+	switch len(names) {
+	case 0:
+		return nil, errors.New("no backend is configured")
+	case 1:
+		backend, err := c.NewBackend(names[0])
+		if err != nil {
+			return nil, err
+		}
+		g = backend
+	default:
+		router := NewRouter()
+		router.Timeout = c.BackendTimeout
+		for i, name := range names {
+			backend, err := c.NewBackend(name)
+			if err != nil {
+				return nil, err
+			}
+			weight := 0
+			if i < len(weights) {
+				weight, err = strconv.Atoi(weights[i])
+				if err != nil {
+					return nil, fmt.Errorf("invalid weight for %s: %v", name, err)
+				}
+			}
+			router.Add(backend, weight)
+		}
+		g = router
+	}
+	return WrapFixtures(g, c.FixturesDir, c.FixturesMode)
+}
+
+// NewReviewer creates the Generator that reviews the claims of every page, given the Generator
+// for the pages. Returns nil if pages should not be reviewed.
+func (c *Config) NewReviewer(g Generator) (Generator, error) {
+	switch {
+	case c.Review == "":
+		return nil, nil
+	case c.Review == "same" || c.FixturesMode == "replay":
+		return g, nil
+	}
+	backend, err := c.NewBackend(c.Review)
+	if err != nil {
+		return nil, err
+	}
+	return WrapFixtures(backend, c.FixturesDir, c.FixturesMode)
+}
@@ -0,0 +1,451 @@
+package clickableai
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxBookmarkOwners is the maximum number of sessions and users that can have bookmarks
+	maxBookmarkOwners = 10000
+	// maxCollections is the maximum number of collections per session or user
+	maxCollections = 50
+	// maxBookmarks is the maximum number of bookmarks per collection
+	maxBookmarks = 500
+	// maxExportGenerated is the maximum number of pages that are generated when a collection is exported
+	maxExportGenerated = 5
+	// maxCollectionNameLength is the maximum number of bytes in the name of a collection
+	maxCollectionNameLength = 80
+	// sessionCookie is the name of the cookie that identifies the session, for bookmarks
+	sessionCookie = "session"
+)
+
+// Bookmark is a bookmarked page
+type Bookmark struct {
+	Lang  string    `json:"lang"`
+	Model string    `json:"model,omitempty"`
+	Trail []string  `json:"trail"`
+	Title string    `json:"title"`
+	URL   string    `json:"url"` // the permalink of the page
+	Added time.Time `json:"added"`
+}
+
+// PageID returns the ID of the bookmarked page
+func (b Bookmark) PageID() PageID {
+	return PageID{Lang: b.Lang, Model: b.Model, Trail: b.Trail}
+}
+
+// Collection is a named and ordered list of bookmarks
+type Collection struct {
+	Name      string     `json:"name"`
+	Bookmarks []Bookmark `json:"bookmarks"`
+}
+
+// Bookmarks is a concurrency-safe store of the collections of bookmarks of each owner, which is a
+// session or a user. Bookmarked pages are kept in the page cache, so that they are never evicted.
+type Bookmarks struct {
+	Cache *PageCache
+
+	mu     sync.Mutex
+	owners map[string][]*Collection
+}
+
+// NewBookmarks creates a new and empty Bookmarks that keeps the bookmarked pages in the given cache
+func NewBookmarks(cache *PageCache) *Bookmarks {
+	return &Bookmarks{Cache: cache, owners: make(map[string][]*Collection)}
+}
+
+// Collections returns copies of the collections of the given owner, in the order they were created
+func (b *Bookmarks) Collections(owner string) []Collection {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	collections := make([]Collection, 0, len(b.owners[owner]))
+	for _, c := range b.owners[owner] {
+		collections = append(collections, Collection{Name: c.Name, Bookmarks: append([]Bookmark{}, c.Bookmarks...)})
+	}
+	return collections
+}
+
+// Collection returns a copy of the collection with the given name, if the owner has it
+func (b *Bookmarks) Collection(owner, name string) (Collection, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.find(owner, name)
+	if c == nil {
+		return Collection{}, false
+	}
+	return Collection{Name: c.Name, Bookmarks: append([]Bookmark{}, c.Bookmarks...)}, true
+}
+
+// CreateCollection creates an empty collection with the given name
+func (b *Bookmarks) CreateCollection(owner, name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, err := b.create(owner, name)
+	return err
+}
+
+// RenameCollection gives a collection a new name
+func (b *Bookmarks) RenameCollection(owner, name, newName string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.find(owner, name)
+	if c == nil {
+		return fmt.Errorf("no such collection: %s", name)
+	}
+	newName, err := collectionName(newName)
+	if err != nil {
+		return err
+	}
+	if other := b.find(owner, newName); other != nil && other != c {
+		return fmt.Errorf("there is already a collection named %s", newName)
+	}
+	c.Name = newName
+	return nil
+}
+
+// DeleteCollection deletes a collection and its bookmarks
+func (b *Bookmarks) DeleteCollection(owner, name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	collections := b.owners[owner]
+	for i, c := range collections {
+		if c.Name == name {
+			for _, bookmark := range c.Bookmarks {
+				b.Cache.Release(bookmark.PageID())
+			}
+			b.owners[owner] = append(collections[:i:i], collections[i+1:]...)
+			if len(b.owners[owner]) == 0 {
+				delete(b.owners, owner)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("no such collection: %s", name)
+}
+
+// Add bookmarks the given page at the end of the named collection, which is created if needed.
+// A page that is already in the collection is not added again. Only generated pages can be bookmarked.
+func (b *Bookmarks) Add(owner, collection string, id PageID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.find(owner, collection)
+	if c == nil {
+		var err error
+		if c, err = b.create(owner, collection); err != nil {
+			return err
+		}
+	}
+	for _, bookmark := range c.Bookmarks {
+		if bookmark.PageID().Key() == id.Key() {
+			return nil
+		}
+	}
+	if len(c.Bookmarks) >= maxBookmarks {
+		return fmt.Errorf("a collection can have at most %d bookmarks", maxBookmarks)
+	}
+	if err := b.Cache.Keep(id); err != nil {
+		return err
+	}
+	c.Bookmarks = append(c.Bookmarks, Bookmark{
+		Lang:  id.Lang,
+		Model: id.Model,
+		Trail: append([]string{}, id.Trail...),
+		Title: strings.Join(id.Trail, " → "),
+		URL:   Permalink(id),
+		Added: time.Now(),
+	})
+	return nil
+}
+
+// Remove removes the bookmark at the given index of the named collection
+func (b *Bookmarks) Remove(owner, collection string, index int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.find(owner, collection)
+	if c == nil {
+		return fmt.Errorf("no such collection: %s", collection)
+	}
+	if index < 0 || index >= len(c.Bookmarks) {
+		return fmt.Errorf("no bookmark at index %d", index)
+	}
+	b.Cache.Release(c.Bookmarks[index].PageID())
+	c.Bookmarks = append(c.Bookmarks[:index:index], c.Bookmarks[index+1:]...)
+	return nil
+}
+
+// Move moves the bookmark at the given index of a collection to the position "to" of the target
+// collection, which may be the same collection. Positions past the end move the bookmark to the end.
+func (b *Bookmarks) Move(owner, collection string, index int, target string, to int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	from, dest := b.find(owner, collection), b.find(owner, target)
+	if from == nil {
+		return fmt.Errorf("no such collection: %s", collection)
+	}
+	if dest == nil {
+		return fmt.Errorf("no such collection: %s", target)
+	}
+	if index < 0 || index >= len(from.Bookmarks) {
+		return fmt.Errorf("no bookmark at index %d", index)
+	}
+	bookmark := from.Bookmarks[index]
+	if dest != from {
+		for _, other := range dest.Bookmarks {
+			if other.PageID().Key() == bookmark.PageID().Key() {
+				return fmt.Errorf("the page is already in %s", dest.Name)
+			}
+		}
+		if len(dest.Bookmarks) >= maxBookmarks {
+			return fmt.Errorf("a collection can have at most %d bookmarks", maxBookmarks)
+		}
+	}
+	from.Bookmarks = append(from.Bookmarks[:index:index], from.Bookmarks[index+1:]...)
+	to = max(0, min(to, len(dest.Bookmarks)))
+	dest.Bookmarks = append(dest.Bookmarks[:to:to], append([]Bookmark{bookmark}, dest.Bookmarks[to:]...)...)
+	return nil
+}
+
+// find returns the named collection of the owner, or nil. The caller must hold the lock.
+func (b *Bookmarks) find(owner, name string) *Collection {
+	for _, c := range b.owners[owner] {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// create creates an empty collection for the owner. The caller must hold the lock.
+func (b *Bookmarks) create(owner, name string) (*Collection, error) {
+	name, err := collectionName(name)
+	if err != nil {
+		return nil, err
+	}
+	if b.find(owner, name) != nil {
+		return nil, fmt.Errorf("there is already a collection named %s", name)
+	}
+	if _, ok := b.owners[owner]; !ok && len(b.owners) >= maxBookmarkOwners {
+		return nil, errors.New("there are too many sessions with bookmarks")
+	}
+	if len(b.owners[owner]) >= maxCollections {
+		return nil, fmt.Errorf("there can be at most %d collections", maxCollections)
+	}
+	c := &Collection{Name: name, Bookmarks: []Bookmark{}}
+	b.owners[owner] = append(b.owners[owner], c)
+	return c, nil
+}
+
+// collectionName trims the name of a collection, and checks that it is not empty or too long
+func collectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("the name of the collection must not be empty")
+	}
+	if len(name) > maxCollectionNameLength {
+		return "", fmt.Errorf("the name of the collection is too long (the maximum is %d bytes)", maxCollectionNameLength)
+	}
+	return name, nil
+}
+
+// shiftHeadings makes every heading of a Markdown document outside of code blocks n levels deeper,
+// down to level 6, so that the document can be a section of another document
+func shiftHeadings(markdown string, n int) string {
+	lines := splitLines(markdown)
+	for _, h := range headingLines(lines) {
+		m := atxHeadingRegexp.FindStringSubmatch(lines[h.line])
+		lines[h.line] = strings.Repeat("#", min(h.Level+n, 6)) + " " + m[2]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ExportCollection returns the pages of a collection as a single Markdown document, with the
+// name of the collection as the title and a section for each page. The pages are taken from the
+// page cache, and up to maxExportGenerated pages are generated if they are not there. The sections
+// of the other missing pages link to the pages instead.
+func (s *Server) ExportCollection(ctx context.Context, c Collection) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("# " + c.Name + "\n")
+	generated := 0
+	for _, bookmark := range c.Bookmarks {
+		id := bookmark.PageID()
+		page, ok := s.Cache.Get(id)
+		if (!ok || page.Markdown == "") && generated >= maxExportGenerated {
+			sb.WriteString("\n## " + bookmark.Title + "\n\n")
+			sb.WriteString("[" + bookmark.Title + "](" + strings.TrimSuffix(s.PublicURL, "/") + bookmark.URL + ")\n")
+			continue
+		}
+		if !ok || page.Markdown == "" {
+			generated++
+			resp, sources, err := s.generateMarkdown(ctx, id, "", "", s.MainTemperature, nil)
+			if err != nil {
+				return "", err
+			}
+			page = s.storePage(ctx, id, Version{
+				Kind:        VersionGenerated,
+				Markdown:    resp.Text,
+				Backend:     resp.Backend,
+				Temperature: s.MainTemperature,
+				Sources:     sources,
+			})
+		}
+		sb.WriteString("\n## " + bookmark.Title + "\n\n")
+		sb.WriteString(strings.TrimSpace(shiftHeadings(page.Markdown, 2)) + "\n")
+	}
+	return sb.String(), nil
+}
+
+// owner returns the user or the session that bookmarks belong to. If there is no user or session
+// yet and create is true, a new session cookie is set. Returns an empty string if there is none.
+func (s *Server) owner(w http.ResponseWriter, r *http.Request, create bool) string {
+	if name := userName(r); name != "" {
+		return "user:" + name
+	}
+	if cookie, err := r.Cookie(sessionCookie); err == nil && len(cookie.Value) == 32 {
+		return "session:" + cookie.Value
+	}
+	if !create {
+		return ""
+	}
+	data := make([]byte, 16)
+	if _, err := rand.Read(data); err != nil {
+		log.Println("Error:", err)
+		return ""
+	}
+	session := hex.EncodeToString(data)
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: session, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode, MaxAge: 365 * 24 * 60 * 60})
+	return "session:" + session
+}
+
+// bookmarksResponse is the JSON response from the bookmark endpoints
+type bookmarksResponse struct {
+	Collections []Collection `json:"collections"`
+}
+
+// bookmarksHandler shows the bookmarks of the session
+func (s *Server) bookmarksHandler(w http.ResponseWriter, r *http.Request) {
+	var collections []Collection
+	if owner := s.owner(w, r, false); owner != "" {
+		collections = s.Bookmarks.Collections(owner)
+	}
+	s.render(w, r, s.bookmarksTmpl, PageData{Collections: collections})
+}
+
+// bookmarksAPIHandler returns the bookmarks of the session as JSON
+func (s *Server) bookmarksAPIHandler(w http.ResponseWriter, r *http.Request) {
+	collections := []Collection{}
+	if owner := s.owner(w, r, false); owner != "" {
+		collections = s.Bookmarks.Collections(owner)
+	}
+	writeJSON(w, bookmarksResponse{Collections: collections})
+}
+
+// bookmarkActionHandler changes the bookmarks of the session with the given function, and returns
+// the changed bookmarks as JSON. The session is created if needed.
+func (s *Server) bookmarkActionHandler(action func(r *http.Request, owner string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Error: Use POST", http.StatusMethodNotAllowed)
+			return
+		}
+		r.ParseForm()
+		owner := s.owner(w, r, true)
+		if owner == "" {
+			http.Error(w, "Error: Could not create a session", http.StatusInternalServerError)
+			return
+		}
+		if err := action(r, owner); err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, bookmarksResponse{Collections: s.Bookmarks.Collections(owner)})
+	}
+}
+
+// addBookmark bookmarks the page given by the usual fields in the "collection", by default the first collection
+func (s *Server) addBookmark(r *http.Request, owner string) error {
+	id := s.pageID(r)
+	if len(id.Trail) == 0 {
+		return errors.New("no keywords given")
+	}
+	collection := strings.TrimSpace(r.FormValue("collection"))
+	if collection == "" {
+		if collections := s.Bookmarks.Collections(owner); len(collections) > 0 {
+			collection = collections[0].Name
+		} else {
+			collection = UIStringsFor(id.Lang).Bookmarks
+		}
+	}
+	return s.Bookmarks.Add(owner, collection, id)
+}
+
+// removeBookmark removes the bookmark at the "index" of the "collection"
+func (s *Server) removeBookmark(r *http.Request, owner string) error {
+	index, err := strconv.Atoi(r.FormValue("index"))
+	if err != nil {
+		return errors.New("invalid index")
+	}
+	return s.Bookmarks.Remove(owner, r.FormValue("collection"), index)
+}
+
+// moveBookmark moves the bookmark at the "index" of the "collection" to the position "to" of the
+// "target" collection, or of the same collection if no target is given
+func (s *Server) moveBookmark(r *http.Request, owner string) error {
+	index, err := strconv.Atoi(r.FormValue("index"))
+	if err != nil {
+		return errors.New("invalid index")
+	}
+	to, err := strconv.Atoi(r.FormValue("to"))
+	if err != nil {
+		return errors.New("invalid position")
+	}
+	collection := r.FormValue("collection")
+	target := r.FormValue("target")
+	if target == "" {
+		target = collection
+	}
+	return s.Bookmarks.Move(owner, collection, index, target, to)
+}
+
+// createCollection creates a collection with the given "name"
+func (s *Server) createCollection(r *http.Request, owner string) error {
+	return s.Bookmarks.CreateCollection(owner, r.FormValue("name"))
+}
+
+// renameCollection gives the collection with the given "name" the "new_name"
+func (s *Server) renameCollection(r *http.Request, owner string) error {
+	return s.Bookmarks.RenameCollection(owner, r.FormValue("name"), r.FormValue("new_name"))
+}
+
+// deleteCollection deletes the collection with the given "name"
+func (s *Server) deleteCollection(r *http.Request, owner string) error {
+	return s.Bookmarks.DeleteCollection(owner, r.FormValue("name"))
+}
+
+// exportCollectionHandler downloads the collection with the given "name" as a Markdown document
+func (s *Server) exportCollectionHandler(w http.ResponseWriter, r *http.Request) {
+	owner := s.owner(w, r, false)
+	c, ok := s.Bookmarks.Collection(owner, r.FormValue("name"))
+	if owner == "" || !ok {
+		http.Error(w, "Error: No such collection", http.StatusNotFound)
+		return
+	}
+	markdown, err := s.ExportCollection(r.Context(), c)
+	if err != nil {
+		log.Println("Error:", err)
+		http.Error(w, "Error: Could not generate output", http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", Slug(c.Name)+".md"))
+	w.Write([]byte(markdown))
+}
@@ -0,0 +1,56 @@
+package clickableai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBookmarkLimits(t *testing.T) {
+	cache := NewPageCache(0)
+	cache.MaxKept = 2
+	b := NewBookmarks(cache)
+	ids := make([]PageID, 3)
+	for i := range ids {
+		ids[i] = PageID{Lang: "en", Trail: []string{fmt.Sprintf("Topic %d", i)}}
+	}
+	if err := b.Add("session:a", "Reading", ids[0]); err == nil {
+		t.Error("a page that has not been generated was bookmarked")
+	}
+	for _, id := range ids {
+		cache.AddVersion(id, Version{Kind: VersionGenerated, Markdown: "# " + id.Trail[0], Backend: "fake"})
+	}
+	for _, id := range ids[:2] {
+		if err := b.Add("session:a", "Reading", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Pages that are already kept can be bookmarked by others
+	if err := b.Add("session:b", "Reading", ids[0]); err != nil {
+		t.Error(err)
+	}
+	if err := b.Add("session:b", "Reading", ids[2]); err == nil {
+		t.Error("more pages than MaxKept were bookmarked")
+	}
+}
+
+func TestExportCollectionLimit(t *testing.T) {
+	s := NewServer(NewFake(1))
+	c := Collection{Name: "Reading"}
+	for i := range maxExportGenerated + 2 {
+		id := PageID{Lang: "en", Trail: []string{fmt.Sprintf("Topic %d", i)}}
+		c.Bookmarks = append(c.Bookmarks, Bookmark{Lang: id.Lang, Trail: id.Trail, Title: id.Trail[0], URL: Permalink(id)})
+	}
+	markdown, err := s.ExportCollection(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if generated := s.Cache.Len(); generated != maxExportGenerated {
+		t.Errorf("%d pages were generated, want %d", generated, maxExportGenerated)
+	}
+	last := c.Bookmarks[len(c.Bookmarks)-1]
+	if !strings.Contains(markdown, "["+last.Title+"]("+last.URL+")") {
+		t.Errorf("the export does not link to the missing page:\n%s", markdown)
+	}
+}
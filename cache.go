@@ -0,0 +1,355 @@
+package clickableai
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PageID identifies a generated page
+type PageID struct {
+	Lang  string
+	Model string // the model that was selected for the session, if any
+	Trail []string
+}
+
+// Key returns the cache key for the page.
+// Pages in different languages or for different models never share a key.
+func (id PageID) Key() string {
+	return id.Lang + "\x00" + id.Model + "\x00" + strings.Join(id.Trail, "\x00")
+}
+
+// The kinds of page versions
+const (
+	VersionGenerated   = "generated"
+	VersionRegenerated = "regenerated"
+	VersionEdited      = "edited"
+)
+
+// maxVersions is the maximum number of versions that are kept per page.
+// The oldest versions are dropped first, but never the pinned version.
+const maxVersions = 20
+
+// Version is one version of the Markdown of a page
+type Version struct {
+	Number      int       `json:"number"` // starts at 1, and is never reused for the same page
+	Kind        string    `json:"kind"`   // generated, regenerated or edited
+	Markdown    string    `json:"markdown"`
+	Backend     string    `json:"backend,omitempty"`
+	Temperature float64   `json:"temperature"`
+	Prompt      string    `json:"prompt,omitempty"` // the version of the prompts, for generated versions
+	Sources     []Passage `json:"sources,omitempty"`
+	Review      *Review   `json:"review,omitempty"`
+	GoChecks    []GoCheck `json:"go_checks,omitempty"`
+	User        string    `json:"user,omitempty"` // the user who regenerated or edited the page, if there is authentication
+	Created     time.Time `json:"created"`
+}
+
+// Page is a generated page, together with the topics that were suggested for it.
+// The Markdown, backend, sources, review and Go checks are those of the canonical version,
+// which is the pinned version if there is one, and the latest version if not.
+type Page struct {
+	PageID
+	Markdown string
+	Topics   []Topic
+	Backend  string    // the name of the backend that generated the Markdown
+	Sources  []Passage // the documentation passages that were given to the backend
+	Review   *Review   // the claims of the page, if it has been reviewed
+	GoChecks []GoCheck // the results of checking the Go code blocks, if they have been checked
+	Created  time.Time
+	Versions []Version  // the versions of the Markdown, oldest first
+	Version  int        // the number of the canonical version, 0 if there are no versions
+	Pinned   int        // the number of the pinned version, 0 if no version is pinned
+	Feedback []Feedback // the feedback on the page and its topics, oldest first
+}
+
+// FindVersion returns the version with the given number, if the page has it
+func (p Page) FindVersion(number int) (Version, bool) {
+	for _, v := range p.Versions {
+		if v.Number == number {
+			return v, true
+		}
+	}
+	return Version{}, false
+}
+
+// WithVersion returns a copy of the page that shows the given version
+func (p Page) WithVersion(v Version) Page {
+	p.Markdown = v.Markdown
+	p.Backend = v.Backend
+	p.Sources = v.Sources
+	p.Review = v.Review
+	p.GoChecks = v.GoChecks
+	p.Version = v.Number
+	return p
+}
+
+// cacheEntry is a cached page, together with when it was last used
+type cacheEntry struct {
+	page     Page
+	lastUsed time.Time
+}
+
+// defaultMaxKept is the default maximum number of kept pages
+const defaultMaxKept = 10000
+
+// PageCache is a concurrency-safe in-memory store of generated pages.
+// If MaxPages is larger than 0, the least recently used pages are evicted when the cache is full.
+// Pages that are kept, like bookmarked pages, are never evicted, and do not count towards MaxPages.
+type PageCache struct {
+	MaxPages int
+	MaxKept  int        // the maximum number of kept pages, 0 for no limit
+	OnEvict  func(Page) // called for every evicted page, while the cache is locked, may be nil
+
+	mu    sync.RWMutex
+	pages map[string]*cacheEntry
+	kept  map[string]int // the number of times each page is kept, by key
+}
+
+// NewPageCache creates a new and empty PageCache that can hold up to maxPages pages (0 for no limit)
+func NewPageCache(maxPages int) *PageCache {
+	return &PageCache{MaxPages: maxPages, MaxKept: defaultMaxKept, pages: make(map[string]*cacheEntry), kept: make(map[string]int)}
+}
+
+// Keep makes sure that the page with the given ID is never evicted, also if it is purged and generated
+// again later, until Release has been called as many times as Keep. Only generated pages can be kept,
+// and no more than MaxKept different pages.
+func (pc *PageCache) Keep(id PageID) error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	key := id.Key()
+	if entry, ok := pc.pages[key]; !ok || len(entry.page.Versions) == 0 {
+		return errors.New("the page has not been generated")
+	}
+	if _, ok := pc.kept[key]; !ok && pc.MaxKept > 0 && len(pc.kept) >= pc.MaxKept {
+		return fmt.Errorf("at most %d pages can be bookmarked", pc.MaxKept)
+	}
+	pc.kept[key]++
+	return nil
+}
+
+// Release undoes one call to Keep for the page with the given ID
+func (pc *PageCache) Release(id PageID) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	key := id.Key()
+	if pc.kept[key] <= 1 {
+		delete(pc.kept, key)
+		return
+	}
+	pc.kept[key]--
+}
+
+// Kept returns the number of cached pages that are kept
+func (pc *PageCache) Kept() int {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	return pc.keptCount()
+}
+
+// Get returns a copy of the cached page with the given ID, if there is one
+func (pc *PageCache) Get(id PageID) (Page, bool) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	entry, ok := pc.pages[id.Key()]
+	if !ok {
+		return Page{}, false
+	}
+	entry.lastUsed = time.Now()
+	return entry.page.copy(), true
+}
+
+// WhileCached calls f with the page with the given ID while the cache is locked, so that the
+// page can not be evicted meanwhile, and returns false if the page is not cached. The function
+// must not modify the page or use the cache.
+func (pc *PageCache) WhileCached(id PageID, f func(Page)) bool {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	entry, ok := pc.pages[id.Key()]
+	if ok {
+		f(entry.page)
+	}
+	return ok
+}
+
+// Pages returns copies of all cached pages, in no particular order
+func (pc *PageCache) Pages() []Page {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	pages := make([]Page, 0, len(pc.pages))
+	for _, entry := range pc.pages {
+		pages = append(pages, entry.page.copy())
+	}
+	return pages
+}
+
+// Len returns the number of cached pages
+func (pc *PageCache) Len() int {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	return len(pc.pages)
+}
+
+// Purge removes the cached pages that match, and returns how many were removed. OnEvict is called
+// for every removed page. Kept pages are removed too, but stay kept, so that they are generated
+// again when they are opened.
+func (pc *PageCache) Purge(match func(Page) bool) int {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	purged := 0
+	for key, entry := range pc.pages {
+		if !match(entry.page) {
+			continue
+		}
+		if pc.OnEvict != nil {
+			pc.OnEvict(entry.page)
+		}
+		delete(pc.pages, key)
+		purged++
+	}
+	return purged
+}
+
+// AddVersion stores a new version of the Markdown for the page with the given ID. The new version
+// becomes the canonical version, unless another version is pinned. Returns the stored version.
+func (pc *PageCache) AddVersion(id PageID, v Version) Version {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	page := pc.page(id)
+	v.Number = 1
+	if n := len(page.Versions); n > 0 {
+		v.Number = page.Versions[n-1].Number + 1
+	}
+	v.Created = time.Now()
+	page.Versions = append(page.Versions, v)
+	if len(page.Versions) > maxVersions {
+		for i, old := range page.Versions {
+			if old.Number != page.Pinned {
+				page.Versions = append(page.Versions[:i:i], page.Versions[i+1:]...)
+				break
+			}
+		}
+	}
+	if page.Pinned == 0 {
+		*page = page.WithVersion(v)
+	}
+	return v
+}
+
+// Pin makes the version with the given number the canonical version of the page with the given ID,
+// also when newer versions are added. Pinning version 0 makes the latest version canonical again.
+// Returns false if there is no such page or version.
+func (pc *PageCache) Pin(id PageID, number int) (Page, bool) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	entry, ok := pc.pages[id.Key()]
+	if !ok || len(entry.page.Versions) == 0 {
+		return Page{}, false
+	}
+	page := &entry.page
+	v := page.Versions[len(page.Versions)-1]
+	if number != 0 {
+		if v, ok = page.FindVersion(number); !ok {
+			return Page{}, false
+		}
+	}
+	*page = page.WithVersion(v)
+	page.Pinned = number
+	return page.copy(), true
+}
+
+// AddFeedback stores feedback on the page with the given ID, and returns the page. Earlier feedback
+// from the same owner on the same version and topic is replaced. Returns false if there is no such page.
+func (pc *PageCache) AddFeedback(id PageID, f Feedback) (Page, bool) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	entry, ok := pc.pages[id.Key()]
+	if !ok {
+		return Page{}, false
+	}
+	page := &entry.page
+	f.Created = time.Now()
+	if f.Owner != "" {
+		page.Feedback = slices.DeleteFunc(page.Feedback, func(earlier Feedback) bool {
+			return earlier.Owner == f.Owner && earlier.Version == f.Version && earlier.Topic == f.Topic
+		})
+	}
+	page.Feedback = append(page.Feedback, f)
+	if len(page.Feedback) > maxFeedback {
+		page.Feedback = append([]Feedback{}, page.Feedback[len(page.Feedback)-maxFeedback:]...)
+	}
+	return page.copy(), true
+}
+
+// SetTopics stores suggested topics for the page with the given ID
+func (pc *PageCache) SetTopics(id PageID, topics []Topic) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.page(id).Topics = topics
+}
+
+// copy returns a copy of the page that does not share the versions and feedback with the cache
+func (p *Page) copy() Page {
+	page := *p
+	page.Versions = append([]Version{}, p.Versions...)
+	page.Feedback = append([]Feedback{}, p.Feedback...)
+	return page
+}
+
+// page returns the page with the given ID, creating it if needed.
+// The caller must hold the write lock.
+func (pc *PageCache) page(id PageID) *Page {
+	key := id.Key()
+	entry, ok := pc.pages[key]
+	if !ok {
+		pc.evict()
+		id.Trail = append([]string{}, id.Trail...)
+		entry = &cacheEntry{page: Page{
+			PageID:  id,
+			Created: time.Now(),
+		}}
+		pc.pages[key] = entry
+	}
+	entry.lastUsed = time.Now()
+	return &entry.page
+}
+
+// evict removes the least recently used pages that are not kept, until there is room for one more page.
+// The caller must hold the write lock.
+func (pc *PageCache) evict() {
+	for pc.MaxPages > 0 && len(pc.pages)-pc.keptCount() >= pc.MaxPages {
+		var (
+			oldestKey  string
+			oldestTime time.Time
+		)
+		for key, entry := range pc.pages {
+			if pc.kept[key] > 0 {
+				continue
+			}
+			if oldestKey == "" || entry.lastUsed.Before(oldestTime) {
+				oldestKey, oldestTime = key, entry.lastUsed
+			}
+		}
+		if oldestKey == "" {
+			return
+		}
+		if pc.OnEvict != nil {
+			pc.OnEvict(pc.pages[oldestKey].page)
+		}
+		delete(pc.pages, oldestKey)
+	}
+}
+
+// keptCount returns the number of cached pages that are kept. The caller must hold a lock.
+func (pc *PageCache) keptCount() int {
+	count := 0
+	for key := range pc.kept {
+		if _, ok := pc.pages[key]; ok {
+			count++
+		}
+	}
+	return count
+}
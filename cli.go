@@ -0,0 +1,27 @@
+package clickableai
+
+import (
+	"io"
+	"log"
+	"os"
+)
+
+// Main loads the configuration and runs the web server.
+// defaultBackend is used if no backend is configured by a file, the environment or the -backend flag.
+func Main(defaultBackend string) {
+	c := DefaultConfig()
+	c.Backend = defaultBackend
+	if err := c.Load(os.Args[1:]); err != nil {
+		log.Fatalln("Error:", err)
+	}
+	if err := c.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v\n", err)
+	}
+	s, err := NewServerFromConfig(c)
+	if err != nil {
+		log.Fatalln("Error:", err)
+	}
+	// The logged errors are shown on the admin dashboard
+	log.SetOutput(io.MultiWriter(os.Stderr, s.Metrics))
+	log.Fatal(s.ListenAndServe(c.Addr))
+}
@@ -13,9 +13,27 @@ var tmpl *template.Template
 
 // PageData holds the data to be rendered in the HTML template
 type PageData struct {
-	Keywords       []string
+	Keywords       []Topic
 	MarkdownOutput template.HTML
 	ExtraInHead    template.HTML
+	Lang           string
+	UI             UIStrings
+	ModelPicker    bool      // show the model picker, for when Ollama is used
+	ImageUpload    bool      // show the image upload
+	Trail          []string  // the trail to open, if any
+	TOC            []Heading // the table of contents of the page to open, if it has been generated
+	Model          string    // the model of the page to open, if any
+	Query          string    // the search query, for the search page
+	Results        []SearchResult
+	Regenerating   bool         // if the user can regenerate pages
+	Editing        bool         // if the user can edit pages and pin versions
+	EditToken      bool         // if editing needs the edit token, since there is no authentication
+	User           *User        // the logged in user, if any
+	History        *HistoryData // the version history, for the history page
+	Report         []PageReport // the worst-rated pages, for the feedback report
+	Meta           *PageMeta    // the metadata of the page to open, for link previews
+	Collections    []Collection // the bookmarks of the session, for the bookmarks page
+	Admin          *AdminData   // the metrics and backends, for the admin dashboard
 }
 
 // InitTemplate initializes the template with the provided HTML content
@@ -23,12 +41,16 @@ func InitTemplate(indexHTML string) {
 	tmpl = template.Must(template.New("index").Parse(indexHTML))
 }
 
-// Handler handles the main page rendering with provided keywords and markdown content
+// Handler handles the main page rendering with provided keywords and markdown content.
+// The UI strings are localised to the language negotiated for the request.
 func Handler(w http.ResponseWriter, r *http.Request, keywords []string, markdown string, extraInHead string) {
+	lang := NegotiateLanguage(r)
 	data := PageData{
-		Keywords:       keywords,
+		Keywords:       NewTopics(keywords, SourceGlossary),
 		MarkdownOutput: template.HTML(markdown),
 		ExtraInHead:    template.HTML(extraInHead),
+		Lang:           lang,
+		UI:             UIStringsFor(lang),
 	}
 
 	var buf bytes.Buffer
@@ -0,0 +1,10 @@
+// Command clickableai runs the clickableai web server, with a backend selected by the -backend flag
+package main
+
+import (
+	"github.com/xyproto/clickableai"
+)
+
+func main() {
+	clickableai.Main("gemini")
+}
@@ -0,0 +1,38 @@
+// Command persona creates the clickableai persona model in Ollama, from the embedded Modelfile template
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/xyproto/clickableai"
+	"github.com/xyproto/env/v2"
+	"github.com/xyproto/ollamaclient/v2"
+)
+
+func main() {
+	host := flag.String("ollama-host", env.Str("OLLAMA_HOST", "http://localhost:11434"), "address of the Ollama server")
+	base := flag.String("base", env.Str("OLLAMA_MODEL", clickableai.DefaultConfig().OllamaModel), "base model")
+	mainPrompt := flag.String("main-prompt", env.Str("MAIN_PROMPT", clickableai.DefaultMainPrompt), "prompt for generating pages, added to the system prompt if it is not the default")
+	diagramPrompt := flag.String("diagram-prompt", env.Str("DIAGRAM_PROMPT", clickableai.DefaultDiagramPrompt), "prompt that describes the diagram language, or empty for no diagrams")
+	printOnly := flag.Bool("print", false, "only print the Modelfile")
+	flag.Parse()
+
+	if *printOnly {
+		modelfile, err := clickableai.PersonaModelfile(*base, *mainPrompt, *diagramPrompt)
+		if err != nil {
+			log.Fatalln("Error:", err)
+		}
+		fmt.Print(modelfile)
+		return
+	}
+
+	oc := ollamaclient.New(*base)
+	oc.ServerAddr = *host
+	name, err := clickableai.EnsurePersona(oc, *base, *mainPrompt, *diagramPrompt, true)
+	if err != nil {
+		log.Fatalln("Error:", err)
+	}
+	fmt.Println(name)
+}
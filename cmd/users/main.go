@@ -0,0 +1,64 @@
+// Command users adds, updates and removes the local users in a clickableai users file.
+// The password is read from standard input, so that it is not seen in the process list.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/xyproto/clickableai"
+	"github.com/xyproto/env/v2"
+)
+
+func main() {
+	filename := flag.String("file", env.Str("USERS_FILE", "users.conf"), "users file")
+	role := flag.String("role", clickableai.RoleViewer, "role of the user: "+strings.Join(clickableai.Roles, ", "))
+	remove := flag.Bool("remove", false, "remove the user")
+	noPassword := flag.Bool("no-password", false, "only give the user a role, for proxy authentication")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: users [flags] name")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	name := flag.Arg(0)
+
+	users, err := clickableai.LoadUsers(*filename)
+	if err != nil {
+		log.Fatalln("Error:", err)
+	}
+	if *remove {
+		if !users.Delete(name) {
+			log.Fatalf("Error: There is no user named %s\n", name)
+		}
+	} else {
+		hash := ""
+		if !*noPassword {
+			fmt.Fprintf(os.Stderr, "Password for %s: ", name)
+			password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			password = strings.TrimRight(password, "\r\n")
+			if err != nil && password == "" {
+				log.Fatalln("Error: No password given")
+			}
+			if password == "" {
+				log.Fatalln("Error: The password must not be empty")
+			}
+			if hash, err = clickableai.HashPassword(password); err != nil {
+				log.Fatalln("Error:", err)
+			}
+		}
+		if err := users.Set(name, *role, hash); err != nil {
+			log.Fatalln("Error:", err)
+		}
+	}
+	if err := users.Save(*filename); err != nil {
+		log.Fatalln("Error:", err)
+	}
+}
@@ -0,0 +1,443 @@
+package clickableai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xyproto/env/v2"
+)
+
+// Config holds all the server settings.
+// Each field can be set in a configuration file (by the conf name), by an environment variable
+// (by the env name) and by a command line flag (by the conf name). Flags override environment
+// variables, which override the configuration file, which overrides the defaults.
+type Config struct {
+	Addr           string        `conf:"addr" env:"ADDR" help:"address to listen on"`
+	Backend        string        `conf:"backend" env:"BACKEND" help:"comma-separated list of backends"`
+	BackendWeights string        `conf:"weights" env:"BACKEND_WEIGHTS" help:"comma-separated load balancing weights, one per backend"`
+	BackendTimeout time.Duration `conf:"timeout" env:"BACKEND_TIMEOUT" help:"timeout per backend, when several backends are given"`
+	Review         string        `conf:"review" env:"REVIEW" help:"backend that reviews the claims of every page, \"same\" for the page backends, or empty for no review"`
+	GoCheck        string        `conf:"go-check" env:"GO_CHECK" help:"check the Go code blocks of every page: off, check, or repair (ask the backend once to fix failing blocks)"`
+
+	ProjectID             string `conf:"project-id" env:"PROJECT_ID" help:"Google Cloud project ID, for Gemini"`
+	ProjectLocation       string `conf:"project-location" env:"PROJECT_LOCATION" help:"Google Cloud location, for Gemini"`
+	GeminiModel           string `conf:"gemini-model" env:"GEMINI_MODEL" help:"Gemini model for text"`
+	GeminiMultiModalModel string `conf:"gemini-multimodal-model" env:"GEMINI_MULTIMODAL_MODEL" help:"Gemini model for text and data"`
+	OllamaHost            string `conf:"ollama-host" env:"OLLAMA_HOST" help:"address of the Ollama server"`
+	OllamaModel           string `conf:"ollama-model" env:"OLLAMA_MODEL" help:"Ollama model"`
+	OllamaVisionModel     string `conf:"ollama-vision-model" env:"OLLAMA_VISION_MODEL" help:"Ollama model for uploaded images"`
+	Persona               string `conf:"persona" env:"PERSONA" help:"use the clickableai persona model with Ollama: auto (if it has been created), always or off"`
+	OpenAIBaseURL         string `conf:"openai-base-url" env:"OPENAI_BASE_URL" help:"base URL of the OpenAI-compatible server"`
+	OpenAIModel           string `conf:"openai-model" env:"OPENAI_MODEL" help:"model for the OpenAI-compatible server"`
+	FakeSeed              int64  `conf:"fake-seed" env:"FAKE_SEED" help:"seed for the fake backend"`
+	Embeddings            string `conf:"embeddings" env:"EMBEDDINGS" help:"backend for embeddings: ollama, openai, or empty for lexical similarity"`
+	EmbeddingModel        string `conf:"embedding-model" env:"EMBEDDING_MODEL" help:"model for embeddings"`
+
+	MainTemperature    float64 `conf:"main-temperature" env:"MAIN_TEMPERATURE" help:"temperature when generating pages"`
+	TopicTemperature   float64 `conf:"topic-temperature" env:"TOPIC_TEMPERATURE" help:"temperature when generating topics"`
+	MainPrompt         string  `conf:"main-prompt" env:"MAIN_PROMPT" help:"prompt for generating pages"`
+	TopicPrompt        string  `conf:"topic-prompt" env:"TOPIC_PROMPT" help:"prompt for generating topics"`
+	GeneralTopicPrompt string  `conf:"general-topic-prompt" env:"GENERAL_TOPIC_PROMPT" help:"prompt for generating general topics"`
+	ImagePrompt        string  `conf:"image-prompt" env:"IMAGE_PROMPT" help:"prompt for generating pages from uploaded images"`
+	DiagramPrompt      string  `conf:"diagram-prompt" env:"DIAGRAM_PROMPT" help:"prompt that describes the diagram language, or empty for no diagrams"`
+	InitialTopics      string  `conf:"topics" env:"TOPICS" help:"comma-separated list of initial topics"`
+	TopicSimilarity    float64 `conf:"topic-similarity" env:"TOPIC_SIMILARITY" help:"similarity from 0 to 1 above which suggested topics are duplicates, 0 to disable deduplication and ranking"`
+
+	CacheSize       int `conf:"cache-size" env:"CACHE_SIZE" help:"maximum number of cached pages, 0 for no limit"`
+	MaxTrail        int `conf:"max-trail" env:"MAX_TRAIL" help:"maximum number of keywords in a trail, 0 for no limit"`
+	MaxMarkdownSize int `conf:"max-markdown-size" env:"MAX_MARKDOWN_SIZE" help:"maximum number of bytes of Markdown given when generating topics, 0 for no limit"`
+	MaxConcurrent   int `conf:"max-concurrent" env:"MAX_CONCURRENT" help:"maximum number of concurrent requests to the backends, 0 for no limit"`
+	MaxImageSize    int `conf:"max-image-size" env:"MAX_IMAGE_SIZE" help:"maximum number of bytes in an uploaded image, 0 to disable image uploads"`
+
+	EditToken       string `conf:"edit-token" env:"EDIT_TOKEN" help:"token that trusted users give for editing pages and pinning versions, or empty to disable editing"`
+	RegenerateScore int    `conf:"regenerate-score" env:"REGENERATE_SCORE" help:"regenerate a page when the feedback score of its current version falls to this negative score, 0 to never regenerate"`
+	PublicURL       string `conf:"public-url" env:"PUBLIC_URL" help:"public base URL of the server, like https://example.com, for link previews, or empty to take it from each request"`
+
+	Auth              string `conf:"auth" env:"AUTH" help:"authentication: off, basic (local users with HTTP Basic) or proxy (users given in a header by a trusted reverse proxy)"`
+	UsersFile         string `conf:"users" env:"USERS_FILE" help:"file with local users, one name:role:hash per line, made with the users command"`
+	AuthUserHeader    string `conf:"auth-user-header" env:"AUTH_USER_HEADER" help:"header with the user name, for proxy authentication"`
+	AuthRoleHeader    string `conf:"auth-role-header" env:"AUTH_ROLE_HEADER" help:"header with the role of the user, for proxy authentication, like X-Forwarded-Role, or empty to not trust one"`
+	AuthDefaultRole   string `conf:"auth-default-role" env:"AUTH_DEFAULT_ROLE" help:"role of proxy users that are not in the users file and have no role header"`
+	AuthProxies       string `conf:"auth-proxies" env:"AUTH_PROXIES" help:"comma-separated addresses or CIDR ranges of the reverse proxies that are trusted to give the user header"`
+	AuthAnonymousRole string `conf:"auth-anonymous-role" env:"AUTH_ANONYMOUS_ROLE" help:"role of requests without a user, like viewer, or empty to make them log in"`
+
+	DocsDir      string `conf:"docs" env:"DOCS_DIR" help:"directory with Markdown, text and HTML documentation to use when generating pages"`
+	DocsPassages int    `conf:"docs-passages" env:"DOCS_PASSAGES" help:"number of documentation passages to give to the backend per page"`
+
+	FixturesDir  string `conf:"fixtures" env:"FIXTURES_DIR" help:"directory for recorded fixtures"`
+	FixturesMode string `conf:"fixtures-mode" env:"FIXTURES_MODE" help:"record or replay backend responses"`
+
+	persona *string // the persona model, once it has been looked up
+}
+
+// DefaultConfig returns a Config with the built-in defaults
+func DefaultConfig() *Config {
+	return &Config{
+		Addr:                  ":8080",
+		Backend:               "gemini",
+		BackendTimeout:        2 * time.Minute,
+		GoCheck:               "off",
+		ProjectLocation:       "europe-north1",
+		GeminiModel:           DefaultGeminiModel,
+		GeminiMultiModalModel: DefaultGeminiMultiModalModel,
+		OllamaHost:            "http://localhost:11434",
+		OllamaModel:           "gemma2:2b",
+		OllamaVisionModel:     "llava",
+		Persona:               "auto",
+		OpenAIBaseURL:         defaultOpenAIBaseURL,
+		EmbeddingModel:        "nomic-embed-text",
+		MainTemperature:       0.0,
+		TopicTemperature:      0.5,
+		MainPrompt:            DefaultMainPrompt,
+		TopicPrompt:           DefaultTopicPrompt,
+		GeneralTopicPrompt:    DefaultGeneralTopicPrompt,
+		ImagePrompt:           DefaultImagePrompt,
+		DiagramPrompt:         DefaultDiagramPrompt,
+		InitialTopics:         strings.Join(DefaultTopics(), ", "),
+		CacheSize:             10000,
+		MaxTrail:              20,
+		MaxMarkdownSize:       64 * 1024,
+		MaxImageSize:          10 * 1024 * 1024,
+		DocsPassages:          3,
+		FixturesDir:           "fixtures",
+		Auth:                  "off",
+		AuthUserHeader:        "X-Forwarded-User",
+		AuthDefaultRole:       RoleViewer,
+		AuthProxies:           "127.0.0.1, ::1",
+	}
+}
+
+// Load updates the configuration from a configuration file, the environment and the given
+// command line arguments, in increasing order of precedence. The configuration file is given
+// by the -config flag or the CLICKABLEAI_CONFIG environment variable.
+func (c *Config) Load(args []string) error {
+	flagConfig := *c
+	flags := flag.NewFlagSet("clickableai", flag.ContinueOnError)
+	configFile := flags.String("config", env.Str("CLICKABLEAI_CONFIG"), "configuration file (JSON, or key = value lines)")
+	byFlag := map[string]reflect.Value{}
+	fv := reflect.ValueOf(&flagConfig).Elem()
+	for i := 0; i < fv.NumField(); i++ {
+		field := fv.Type().Field(i)
+		name := field.Tag.Get("conf")
+		if name == "" {
+			continue
+		}
+		flags.Var(configValue{fv.Field(i)}, name, field.Tag.Get("help"))
+		byFlag[name] = reflect.ValueOf(c).Elem().Field(i)
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *configFile != "" {
+		if err := c.LoadFile(*configFile); err != nil {
+			return err
+		}
+	}
+	if err := c.LoadEnv(); err != nil {
+		return err
+	}
+
+	// Only the flags that were given override the configuration file and the environment
+	flags.Visit(func(f *flag.Flag) {
+		if dst, ok := byFlag[f.Name]; ok {
+			dst.Set(f.Value.(configValue).v)
+		}
+	})
+	return nil
+}
+
+// LoadFile updates the configuration from a file. Files ending with .json are read as a JSON
+// object. Other files are read as "key = value" lines, where lines starting with # or ; are
+// comments, values may be quoted and [section] headers are ignored, which covers simple
+// TOML and INI files.
+func (c *Config) LoadFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("could not read configuration file: %v", err)
+	}
+	values := map[string]string{}
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		var obj map[string]any
+		// Numbers are kept as they were written, since large integers would be formatted as floats
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&obj); err != nil {
+			return fmt.Errorf("%s: %v", filename, err)
+		}
+		if decoder.More() {
+			return fmt.Errorf("%s: unexpected data after the JSON object", filename)
+		}
+		for key, value := range obj {
+			values[key] = fmt.Sprint(value)
+		}
+	} else {
+		scanner := bufio.NewScanner(strings.NewReader(string(data)))
+		lineNumber := 0
+		for scanner.Scan() {
+			lineNumber++
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "[") {
+				continue
+			}
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return fmt.Errorf("%s:%d: expected key = value", filename, lineNumber)
+			}
+			value = strings.TrimSpace(value)
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			}
+			values[strings.TrimSpace(key)] = value
+		}
+	}
+	return c.set(values, func(key string) string {
+		return filename + ": " + key
+	})
+}
+
+// LoadEnv updates the configuration from the environment variables that are set.
+// PORT is also supported, as a shorthand for ADDR=:$PORT.
+func (c *Config) LoadEnv() error {
+	if port := env.Str("PORT"); port != "" {
+		c.Addr = ":" + port
+	}
+	values := map[string]string{}
+	t := reflect.TypeOf(c).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		if value := env.Str(t.Field(i).Tag.Get("env")); value != "" {
+			values[t.Field(i).Tag.Get("conf")] = value
+		}
+	}
+	return c.set(values, func(key string) string {
+		return "environment variable " + envName(key)
+	})
+}
+
+// set updates the fields with the given conf names. describe returns a
+// description of where a key came from, for use in error messages.
+func (c *Config) set(values map[string]string, describe func(key string) string) error {
+	v := reflect.ValueOf(c).Elem()
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var errs []error
+	for _, key := range keys {
+		value := values[key]
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Tag.Get("conf") == key {
+				found = true
+				if err := setField(v.Field(i), value); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %v", describe(key), err))
+				}
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("%s: unknown setting", describe(key)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks that the configuration is usable, and returns all the problems that were found
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Addr == "" {
+		errs = append(errs, errors.New("addr: must not be empty"))
+	}
+	backends := SplitTopics(c.Backend)
+	if len(backends) == 0 && c.FixturesMode != "replay" {
+		errs = append(errs, errors.New("backend: at least one backend must be given"))
+	}
+	for _, name := range backends {
+		if !contains(BackendNames, name) {
+			errs = append(errs, fmt.Errorf("backend: unknown backend %q (available backends: %s)", name, strings.Join(BackendNames, ", ")))
+		}
+		if name == "gemini" && c.ProjectID == "" && c.FixturesMode != "replay" {
+			errs = append(errs, errors.New("project-id: must be set when using the gemini backend"))
+		}
+	}
+	if c.DocsDir != "" {
+		if info, err := os.Stat(c.DocsDir); err != nil {
+			errs = append(errs, fmt.Errorf("docs: %v", err))
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("docs: %s is not a directory", c.DocsDir))
+		}
+	}
+	if c.DocsPassages < 0 {
+		errs = append(errs, errors.New("docs-passages: must not be negative"))
+	}
+	if !contains(EmbeddingBackends, c.Embeddings) {
+		errs = append(errs, fmt.Errorf("embeddings: unknown backend %q (available backends: ollama, openai)", c.Embeddings))
+	}
+	if c.TopicSimilarity < 0 || c.TopicSimilarity > 1 {
+		errs = append(errs, fmt.Errorf("topic-similarity: %g is outside of the range 0 to 1", c.TopicSimilarity))
+	}
+	if !contains(personaModes, c.Persona) {
+		errs = append(errs, fmt.Errorf("persona: %q is not one of %s", c.Persona, strings.Join(personaModes, ", ")))
+	}
+	if c.Review != "" && c.Review != "same" && !contains(BackendNames, c.Review) {
+		errs = append(errs, fmt.Errorf("review: unknown backend %q (available backends: same, %s)", c.Review, strings.Join(BackendNames, ", ")))
+	}
+	if !contains(GoCheckModes, c.GoCheck) {
+		errs = append(errs, fmt.Errorf("go-check: %q is not one of %s", c.GoCheck, strings.Join(GoCheckModes, ", ")))
+	}
+	if c.PublicURL != "" {
+		if u, err := url.Parse(c.PublicURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("public-url: %q is not an http or https URL", c.PublicURL))
+		}
+	}
+	switch c.Auth {
+	case "off":
+	case "basic":
+		if c.UsersFile == "" {
+			errs = append(errs, errors.New("users: must be set when auth is basic"))
+		}
+	case "proxy":
+		if c.AuthUserHeader == "" {
+			errs = append(errs, errors.New("auth-user-header: must be set when auth is proxy"))
+		}
+		if roleLevel(c.AuthDefaultRole) < 0 {
+			errs = append(errs, fmt.Errorf("auth-default-role: unknown role %q (the roles are %s)", c.AuthDefaultRole, strings.Join(Roles, ", ")))
+		}
+		if _, err := ParseProxies(c.AuthProxies); err != nil {
+			errs = append(errs, fmt.Errorf("auth-proxies: %v", err))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("auth: %q must be off, basic or proxy", c.Auth))
+	}
+	if c.AuthAnonymousRole != "" && roleLevel(c.AuthAnonymousRole) < 0 {
+		errs = append(errs, fmt.Errorf("auth-anonymous-role: unknown role %q (the roles are %s)", c.AuthAnonymousRole, strings.Join(Roles, ", ")))
+	}
+	if c.RegenerateScore > 0 {
+		errs = append(errs, fmt.Errorf("regenerate-score: %d must be negative, or 0 to never regenerate", c.RegenerateScore))
+	}
+	weights := SplitTopics(c.BackendWeights)
+	if len(weights) > len(backends) {
+		errs = append(errs, fmt.Errorf("weights: %d weights given for %d backends", len(weights), len(backends)))
+	}
+	for _, weight := range weights {
+		if n, err := strconv.Atoi(weight); err != nil || n < 0 {
+			errs = append(errs, fmt.Errorf("weights: %q is not a non-negative integer", weight))
+		}
+	}
+	if c.BackendTimeout < 0 {
+		errs = append(errs, errors.New("timeout: must not be negative"))
+	}
+	for _, t := range []struct {
+		name  string
+		value float64
+	}{{"main-temperature", c.MainTemperature}, {"topic-temperature", c.TopicTemperature}} {
+		if t.value < 0 || t.value > 2 {
+			errs = append(errs, fmt.Errorf("%s: %g is outside of the range 0 to 2", t.name, t.value))
+		}
+	}
+	for _, p := range []struct {
+		name  string
+		value string
+	}{{"main-prompt", c.MainPrompt}, {"topic-prompt", c.TopicPrompt}, {"general-topic-prompt", c.GeneralTopicPrompt}, {"image-prompt", c.ImagePrompt}} {
+		if strings.TrimSpace(p.value) == "" {
+			errs = append(errs, fmt.Errorf("%s: must not be empty", p.name))
+		}
+	}
+	if len(SplitTopics(c.InitialTopics)) == 0 {
+		errs = append(errs, errors.New("topics: at least one initial topic must be given"))
+	}
+	for _, l := range []struct {
+		name  string
+		value int
+	}{{"cache-size", c.CacheSize}, {"max-trail", c.MaxTrail}, {"max-markdown-size", c.MaxMarkdownSize}, {"max-concurrent", c.MaxConcurrent}, {"max-image-size", c.MaxImageSize}} {
+		if l.value < 0 {
+			errs = append(errs, fmt.Errorf("%s: must not be negative", l.name))
+		}
+	}
+	switch c.FixturesMode {
+	case "", "record", "replay":
+	default:
+		errs = append(errs, fmt.Errorf("fixtures-mode: %q is not record or replay", c.FixturesMode))
+	}
+	return errors.Join(errs...)
+}
+
+// configValue is a flag.Value for a field in Config
+type configValue struct {
+	v reflect.Value
+}
+
+// String returns the current value of the field
+func (cv configValue) String() string {
+	if !cv.v.IsValid() {
+		return ""
+	}
+	if d, ok := cv.v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+	return fmt.Sprint(cv.v.Interface())
+}
+
+// Set parses and sets the value of the field
+func (cv configValue) Set(s string) error {
+	return setField(cv.v, s)
+}
+
+// setField parses the given string and stores it in the given field
+func setField(field reflect.Value, s string) error {
+	s = strings.TrimSpace(s)
+	switch field.Interface().(type) {
+	case time.Duration:
+		// Plain numbers are seconds, for compatibility with BACKEND_TIMEOUT
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			field.SetInt(int64(n * float64(time.Second)))
+			return nil
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case string:
+		field.SetString(s)
+	case int, int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		field.SetInt(n)
+	case float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", s)
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Type())
+	}
+	return nil
+}
+
+// envName returns the environment variable name for the given conf name
+func envName(key string) string {
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("conf") == key {
+			return t.Field(i).Tag.Get("env")
+		}
+	}
+	return key
+}
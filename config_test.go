@@ -0,0 +1,22 @@
+package clickableai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFileJSONNumbers(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "clickableai.json")
+	data := `{"max-markdown-size": 10485760, "cache-size": 100000, "topic-temperature": 0.5, "backend": "fake"}`
+	if err := os.WriteFile(filename, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := DefaultConfig()
+	if err := c.LoadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+	if c.MaxMarkdownSize != 10485760 || c.CacheSize != 100000 || c.TopicTemperature != 0.5 || c.Backend != "fake" {
+		t.Errorf("unexpected configuration: %+v", c)
+	}
+}
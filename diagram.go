@@ -0,0 +1,584 @@
+package clickableai
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultDiagramPrompt describes the diagram language to the backend. It is given before the main prompt.
+const DefaultDiagramPrompt = "If a diagram would make the document clearer, add one in a fenced code block with the language \"diagram\". " +
+	"The first line of a diagram is \"flowchart\", \"flowchart LR\" or \"sequence\". Each of the other lines is an arrow like \"Client -> Server: request\", " +
+	"where \": request\" is an optional label and \"-->\" gives a dashed arrow. A flowchart may chain arrows, like \"A -> B -> C\". No other syntax is allowed.\n\n"
+
+// Diagram is a diagram block from a Markdown document, rendered as SVG
+type Diagram struct {
+	Source string        `json:"source"`
+	SVG    template.HTML `json:"svg,omitempty"`   // empty if the source could not be rendered
+	Error  string        `json:"error,omitempty"` // why the source could not be rendered
+}
+
+// The limits of a diagram, so that a backend can not make the server render huge images
+const (
+	maxDiagramNodes = 30
+	maxDiagramEdges = 60
+	maxDiagramLabel = 40 // runes
+)
+
+// RenderDiagrams renders the diagram blocks of a Markdown document, in the order they appear.
+// Blocks that can not be parsed are returned with an error instead of SVG.
+func RenderDiagrams(markdown string) []Diagram {
+	var diagrams []Diagram
+	for _, block := range fencedBlocks(markdown) {
+		if block.lang != "diagram" {
+			continue
+		}
+		svg, err := RenderDiagram(block.source)
+		d := Diagram{Source: block.source, SVG: svg}
+		if err != nil {
+			d.Error = err.Error()
+		}
+		diagrams = append(diagrams, d)
+	}
+	return diagrams
+}
+
+// RenderDiagram parses the source of a diagram and renders it as SVG
+func RenderDiagram(source string) (template.HTML, error) {
+	d, err := parseDiagram(source)
+	if err != nil {
+		return "", err
+	}
+	if d.sequence {
+		return d.renderSequence(), nil
+	}
+	return d.renderFlowchart(), nil
+}
+
+// diagramEdge is an arrow between two nodes of a diagram, given by their indices
+type diagramEdge struct {
+	from, to int
+	label    string
+	dashed   bool
+}
+
+// diagram is a parsed flowchart or sequence diagram
+type diagram struct {
+	sequence   bool
+	horizontal bool // for flowcharts, left to right instead of top to bottom
+	nodes      []string
+	edges      []diagramEdge
+}
+
+// diagramArrowRegexp matches the arrows of a diagram line
+var diagramArrowRegexp = regexp.MustCompile(`\s*(-->|->)\s*`)
+
+// parseDiagram parses the source of a diagram. Empty lines and lines starting with # are ignored.
+func parseDiagram(source string) (*diagram, error) {
+	d := &diagram{}
+	header := false
+	index := make(map[string]int)
+	node := func(name string) (int, error) {
+		if name == "" {
+			return 0, errors.New("an arrow is missing a node")
+		}
+		if utf8.RuneCountInString(name) > maxDiagramLabel {
+			return 0, fmt.Errorf("the name %q is longer than %d characters", name, maxDiagramLabel)
+		}
+		if i, ok := index[name]; ok {
+			return i, nil
+		}
+		if len(d.nodes) == maxDiagramNodes {
+			return 0, fmt.Errorf("more than %d nodes", maxDiagramNodes)
+		}
+		index[name] = len(d.nodes)
+		d.nodes = append(d.nodes, name)
+		return index[name], nil
+	}
+	for n, line := range strings.Split(source, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !header {
+			fields := strings.Fields(strings.ToLower(line))
+			switch {
+			case fields[0] == "sequence" && len(fields) == 1:
+				d.sequence = true
+			case fields[0] == "flowchart" && len(fields) == 1:
+			case fields[0] == "flowchart" && len(fields) == 2 && (fields[1] == "lr" || fields[1] == "td" || fields[1] == "tb"):
+				d.horizontal = fields[1] == "lr"
+			default:
+				return nil, fmt.Errorf("line %d: the diagram must start with flowchart or sequence", n+1)
+			}
+			header = true
+			continue
+		}
+
+		body, label := line, ""
+		if arrow := strings.LastIndex(line, "->"); arrow != -1 {
+			if colon := strings.Index(line[arrow:], ":"); colon != -1 {
+				body, label = line[:arrow+colon], strings.TrimSpace(line[arrow+colon+1:])
+			}
+		}
+		if utf8.RuneCountInString(label) > maxDiagramLabel {
+			return nil, fmt.Errorf("line %d: the label is longer than %d characters", n+1, maxDiagramLabel)
+		}
+		arrows := diagramArrowRegexp.FindAllStringSubmatchIndex(body, -1)
+		if d.sequence && len(arrows) > 1 {
+			return nil, fmt.Errorf("line %d: a sequence diagram can not chain arrows", n+1)
+		}
+		names := diagramArrowRegexp.Split(body, -1)
+		prev, err := node(strings.TrimSpace(names[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n+1, err)
+		}
+		for i, arrow := range arrows {
+			next, err := node(strings.TrimSpace(names[i+1]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", n+1, err)
+			}
+			if len(d.edges) == maxDiagramEdges {
+				return nil, fmt.Errorf("line %d: more than %d arrows", n+1, maxDiagramEdges)
+			}
+			edge := diagramEdge{from: prev, to: next, dashed: body[arrow[2]:arrow[3]] == "-->"}
+			if i == len(arrows)-1 {
+				edge.label = label
+			}
+			d.edges = append(d.edges, edge)
+			prev = next
+		}
+	}
+	if len(d.nodes) == 0 {
+		return nil, errors.New("the diagram is empty")
+	}
+	return d, nil
+}
+
+// The sizes used when rendering diagrams, in pixels
+const (
+	diagramFontSize  = 13
+	diagramCharWidth = 7.5 // the approximate width of a character
+	diagramMargin    = 10
+	diagramPadding   = 12 // between the text and the border of a box
+	diagramBoxHeight = 34.0
+	diagramArrowSize = 8.0
+)
+
+// The colors used when rendering diagrams
+const (
+	diagramBoxFill   = "#f1f6fd"
+	diagramLineColor = "#007bff"
+	diagramTextColor = "#212529"
+)
+
+// textWidth returns the approximate width of the text when rendered
+func textWidth(text string) float64 {
+	return float64(utf8.RuneCountInString(text)) * diagramCharWidth
+}
+
+// boxWidth returns the width of a box with the given text
+func boxWidth(text string) float64 {
+	return textWidth(text) + 2*diagramPadding
+}
+
+// svgWriter writes the elements of an SVG image
+type svgWriter struct {
+	strings.Builder
+}
+
+// box writes a box with centered text, where x and y are the center of the box
+func (w *svgWriter) box(x, y, width, height float64, text string) {
+	fmt.Fprintf(&w.Builder, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="5" fill="%s" stroke="%s"/>`,
+		x-width/2, y-height/2, width, height, diagramBoxFill, diagramLineColor)
+	w.text(x, y, text)
+}
+
+// text writes centered text, where y is the middle of the text
+func (w *svgWriter) text(x, y float64, text string) {
+	fmt.Fprintf(&w.Builder, `<text x="%.1f" y="%.1f" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`,
+		x, y, diagramTextColor, html.EscapeString(text))
+}
+
+// label writes centered text on a white background, so that it can be read on top of lines
+func (w *svgWriter) label(x, y float64, text string) {
+	if text == "" {
+		return
+	}
+	width := textWidth(text) + 6
+	fmt.Fprintf(&w.Builder, `<rect x="%.1f" y="%.1f" width="%.1f" height="%d" fill="white" fill-opacity="0.85"/>`,
+		x-width/2, y-diagramFontSize/2-2, width, diagramFontSize+4)
+	w.text(x, y, text)
+}
+
+// arrow writes a line from (x1, y1) to (x2, y2) with an arrowhead at (x2, y2)
+func (w *svgWriter) arrow(x1, y1, x2, y2 float64, dashed bool) {
+	length := math.Hypot(x2-x1, y2-y1)
+	if length == 0 {
+		return
+	}
+	ux, uy := (x2-x1)/length, (y2-y1)/length
+	// End the line at the base of the arrowhead, so that it does not show through the tip
+	fmt.Fprintf(&w.Builder, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"%s/>`,
+		x1, y1, x2-ux*diagramArrowSize, y2-uy*diagramArrowSize, diagramLineColor, dashArray(dashed))
+	w.arrowhead(x2, y2, ux, uy)
+}
+
+// arrowhead writes an arrowhead with the tip at (x, y), pointing in the direction (ux, uy)
+func (w *svgWriter) arrowhead(x, y, ux, uy float64) {
+	bx, by := x-ux*diagramArrowSize, y-uy*diagramArrowSize
+	nx, ny := -uy*diagramArrowSize/2, ux*diagramArrowSize/2
+	fmt.Fprintf(&w.Builder, `<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s"/>`,
+		x, y, bx+nx, by+ny, bx-nx, by-ny, diagramLineColor)
+}
+
+// svg returns the written elements in an SVG image of the given size
+func (w *svgWriter) svg(width, height float64, title string) template.HTML {
+	return template.HTML(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" class="diagram" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" role="img" aria-label="%s" font-family="sans-serif" font-size="%d">%s</svg>`,
+		math.Ceil(width), math.Ceil(height), math.Ceil(width), math.Ceil(height), html.EscapeString(title), diagramFontSize, w.String()))
+}
+
+// title returns a description of the diagram, for screen readers
+func (d *diagram) title() string {
+	kind := "Flowchart"
+	if d.sequence {
+		kind = "Sequence diagram"
+	}
+	return kind + ": " + strings.Join(d.nodes, ", ")
+}
+
+// layers places the nodes of a flowchart in layers, so that most arrows point to a later layer.
+// Arrows that close a cycle are ignored. Returns the layers, with the node indices in display order.
+func (d *diagram) layers() [][]int {
+	n := len(d.nodes)
+	outgoing := make([][]int, n)
+	for i, e := range d.edges {
+		outgoing[e.from] = append(outgoing[e.from], i)
+	}
+
+	// Find the arrows that close a cycle with a depth-first search
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, n)
+	backward := make([]bool, len(d.edges))
+	var visit func(v int)
+	visit = func(v int) {
+		state[v] = visiting
+		for _, i := range outgoing[v] {
+			switch to := d.edges[i].to; state[to] {
+			case visiting:
+				backward[i] = true
+			case unvisited:
+				visit(to)
+			}
+		}
+		state[v] = visited
+	}
+	for v := range d.nodes {
+		if state[v] == unvisited {
+			visit(v)
+		}
+	}
+
+	// The layer of a node is the length of the longest path to it
+	layer := make([]int, n)
+	for changed := true; changed; {
+		changed = false
+		for i, e := range d.edges {
+			if !backward[i] && layer[e.to] < layer[e.from]+1 {
+				layer[e.to] = layer[e.from] + 1
+				changed = true
+			}
+		}
+	}
+	var layers [][]int
+	for v, l := range layer {
+		for len(layers) <= l {
+			layers = append(layers, nil)
+		}
+		layers[l] = append(layers[l], v)
+	}
+
+	// Reduce crossings by ordering each layer by the average position of its neighbours
+	// in the layer above, and then in the layer below
+	position := make([]float64, n)
+	updatePositions := func() {
+		for _, nodes := range layers {
+			for i, v := range nodes {
+				position[v] = float64(i)
+			}
+		}
+	}
+	updatePositions()
+	for sweep := 0; sweep < 4; sweep++ {
+		for i := range layers {
+			l := i
+			neighbour := l - 1
+			if sweep%2 == 1 {
+				l = len(layers) - 1 - i
+				neighbour = l + 1
+			}
+			barycenter := make(map[int]float64, len(layers[l]))
+			for _, v := range layers[l] {
+				sum, count := 0.0, 0
+				for _, e := range d.edges {
+					if e.from == v && layer[e.to] == neighbour {
+						sum, count = sum+position[e.to], count+1
+					} else if e.to == v && layer[e.from] == neighbour {
+						sum, count = sum+position[e.from], count+1
+					}
+				}
+				barycenter[v] = position[v]
+				if count > 0 {
+					barycenter[v] = sum / float64(count)
+				}
+			}
+			sort.SliceStable(layers[l], func(a, b int) bool {
+				return barycenter[layers[l][a]] < barycenter[layers[l][b]]
+			})
+			updatePositions()
+		}
+	}
+	return layers
+}
+
+// renderFlowchart renders the diagram as boxes in layers, with arrows between them
+func (d *diagram) renderFlowchart() template.HTML {
+	const (
+		layerGap = 50 // between layers
+		nodeGap  = 24 // between the nodes of a layer
+	)
+	layers := d.layers()
+	x := make([]float64, len(d.nodes))
+	y := make([]float64, len(d.nodes))
+	widths := make([]float64, len(d.nodes))
+	for i, name := range d.nodes {
+		widths[i] = boxWidth(name)
+	}
+	// The room taken by each box, including the loops and labels of arrows to itself
+	extents := append([]float64{}, widths...)
+	for _, e := range d.edges {
+		if e.from == e.to {
+			extents[e.from] = math.Max(extents[e.from], widths[e.from]+30+textWidth(e.label))
+		}
+	}
+
+	// The extent of each layer, along the layer and across it
+	along := make([]float64, len(layers))
+	across := make([]float64, len(layers))
+	for l, nodes := range layers {
+		for i, v := range nodes {
+			if i > 0 {
+				along[l] += nodeGap
+			}
+			if d.horizontal {
+				along[l] += diagramBoxHeight
+				across[l] = math.Max(across[l], extents[v])
+			} else {
+				along[l] += extents[v]
+				across[l] = diagramBoxHeight
+			}
+		}
+	}
+	maxAlong := 0.0
+	for _, a := range along {
+		maxAlong = math.Max(maxAlong, a)
+	}
+	offset := float64(diagramMargin) // across the layers
+	for l, nodes := range layers {
+		pos := diagramMargin + (maxAlong-along[l])/2 // center the layer
+		for _, v := range nodes {
+			size := extents[v]
+			if d.horizontal {
+				size = diagramBoxHeight
+				x[v], y[v] = offset+(across[l]-extents[v]+widths[v])/2, pos+size/2
+			} else {
+				x[v], y[v] = pos+widths[v]/2, offset+across[l]/2
+			}
+			pos += size + nodeGap
+		}
+		offset += across[l] + layerGap
+	}
+	width, height := maxAlong+2*diagramMargin, offset-layerGap+diagramMargin
+	if d.horizontal {
+		width, height = height, width
+	}
+
+	layerOf := make([]int, len(d.nodes))
+	for l, nodes := range layers {
+		for _, v := range nodes {
+			layerOf[v] = l
+		}
+	}
+	// The edge of the diagram, beside which the arrows that skip layers are routed
+	side := 0.0
+	for v := range d.nodes {
+		if d.horizontal {
+			side = math.Max(side, y[v]+diagramBoxHeight/2)
+		} else {
+			side = math.Max(side, x[v]-widths[v]/2+extents[v])
+		}
+	}
+
+	var w svgWriter
+	reverse := make(map[[2]int]bool, len(d.edges))
+	for _, e := range d.edges {
+		reverse[[2]int{e.from, e.to}] = true
+	}
+	for _, e := range d.edges {
+		if e.from == e.to {
+			// A loop on the right side of the box
+			right, top := x[e.from]+widths[e.from]/2, y[e.from]-diagramBoxHeight/4
+			fmt.Fprintf(&w.Builder, `<path d="M %.1f %.1f c 24 -12 24 %.1f 0 %.1f" fill="none" stroke="%s"%s/>`,
+				right, top, diagramBoxHeight/2+12, diagramBoxHeight/2, diagramLineColor, dashArray(e.dashed))
+			w.arrowhead(right, top+diagramBoxHeight/2, -1, 0)
+			w.label(right+24+textWidth(e.label)/2, y[e.from], e.label)
+			continue
+		}
+		if span := layerOf[e.to] - layerOf[e.from]; span != 1 && span != -1 {
+			// Arrows that skip layers or point back to the same layer would cross other boxes,
+			// so they are routed beside the diagram, as a curve
+			side += 20
+			if d.horizontal {
+				x1, y1 := x[e.from], y[e.from]+diagramBoxHeight/2
+				x2, y2 := x[e.to], y[e.to]+diagramBoxHeight/2
+				fmt.Fprintf(&w.Builder, `<path d="M %.1f %.1f C %.1f %.1f %.1f %.1f %.1f %.1f" fill="none" stroke="%s"%s/>`,
+					x1, y1, x1, side, x2, side, x2, y2+diagramArrowSize, diagramLineColor, dashArray(e.dashed))
+				w.arrowhead(x2, y2, 0, -1)
+				w.label((x1+x2)/2, (y1+y2)/8+side*3/4, e.label)
+				height = math.Max(height, side+diagramFontSize+diagramMargin)
+			} else {
+				x1, y1 := x[e.from]+widths[e.from]/2, y[e.from]
+				x2, y2 := x[e.to]+widths[e.to]/2, y[e.to]
+				fmt.Fprintf(&w.Builder, `<path d="M %.1f %.1f C %.1f %.1f %.1f %.1f %.1f %.1f" fill="none" stroke="%s"%s/>`,
+					x1, y1, side, y1, side, y2, x2+diagramArrowSize, y2, diagramLineColor, dashArray(e.dashed))
+				w.arrowhead(x2, y2, -1, 0)
+				labelX := (x1+x2)/8 + side*3/4
+				w.label(labelX, (y1+y2)/2, e.label)
+				width = math.Max(width, math.Max(side, labelX+textWidth(e.label)/2)+diagramMargin)
+			}
+			continue
+		}
+		x1, y1 := boxEdge(x[e.from], y[e.from], widths[e.from], x[e.to], y[e.to])
+		x2, y2 := boxEdge(x[e.to], y[e.to], widths[e.to], x[e.from], y[e.from])
+		if reverse[[2]int{e.to, e.from}] {
+			// Arrows in both directions are drawn side by side
+			length := math.Hypot(x2-x1, y2-y1)
+			nx, ny := -(y2-y1)/length*4, (x2-x1)/length*4
+			x1, y1, x2, y2 = x1+nx, y1+ny, x2+nx, y2+ny
+		}
+		w.arrow(x1, y1, x2, y2, e.dashed)
+		w.label((x1+x2)/2, (y1+y2)/2, e.label)
+	}
+	for i, name := range d.nodes {
+		w.box(x[i], y[i], widths[i], diagramBoxHeight, name)
+	}
+	return w.svg(width, height, d.title())
+}
+
+// boxEdge returns the point where a line from the center of a box towards (tx, ty) crosses its border
+func boxEdge(cx, cy, width, tx, ty float64) (float64, float64) {
+	dx, dy := tx-cx, ty-cy
+	if dx == 0 && dy == 0 {
+		return cx, cy
+	}
+	t := math.Inf(1)
+	if dx != 0 {
+		t = math.Min(t, width/2/math.Abs(dx))
+	}
+	if dy != 0 {
+		t = math.Min(t, diagramBoxHeight/2/math.Abs(dy))
+	}
+	return cx + dx*t, cy + dy*t
+}
+
+// renderSequence renders the diagram as participants with lifelines, and arrows between the
+// lifelines from top to bottom
+func (d *diagram) renderSequence() template.HTML {
+	const (
+		participantGap = 30 // between the boxes of the participants
+		messageGap     = 40 // between the arrows
+		selfWidth      = 30 // the width of an arrow from a participant to itself
+	)
+	n := len(d.nodes)
+	widths := make([]float64, n)
+	for i, name := range d.nodes {
+		widths[i] = boxWidth(name)
+	}
+	// The distance between neighbouring lifelines must fit both the boxes and the labels
+	gaps := make([]float64, n)
+	for i := 1; i < n; i++ {
+		gaps[i] = widths[i-1]/2 + widths[i]/2 + participantGap
+	}
+	extra := 0.0 // room for the labels of arrows from the last participant to itself
+	for _, e := range d.edges {
+		from, to := e.from, e.to
+		if from > to {
+			from, to = to, from
+		}
+		need := textWidth(e.label) + 20
+		if from == to {
+			if to+1 < n {
+				gaps[to+1] = math.Max(gaps[to+1], selfWidth+need)
+			} else {
+				extra = math.Max(extra, selfWidth+need-widths[to]/2)
+			}
+			continue
+		}
+		for i := from + 1; i <= to; i++ {
+			gaps[i] = math.Max(gaps[i], need/float64(to-from))
+		}
+	}
+	x := make([]float64, n)
+	for i := range x {
+		if i == 0 {
+			x[i] = diagramMargin + widths[0]/2
+		} else {
+			x[i] = x[i-1] + gaps[i]
+		}
+	}
+
+	var w svgWriter
+	top := float64(diagramMargin + diagramBoxHeight)
+	y := top + messageGap
+	for _, e := range d.edges {
+		if e.from == e.to {
+			fmt.Fprintf(&w.Builder, `<path d="M %.1f %.1f h %d v %d h %.1f" fill="none" stroke="%s"%s/>`,
+				x[e.from], y-10, selfWidth, 20, -(selfWidth - diagramArrowSize), diagramLineColor, dashArray(e.dashed))
+			w.arrowhead(x[e.from], y+10, -1, 0)
+			w.label(x[e.from]+selfWidth+6+textWidth(e.label)/2, y, e.label)
+			y += messageGap + 10
+			continue
+		}
+		w.arrow(x[e.from], y, x[e.to], y, e.dashed)
+		w.label((x[e.from]+x[e.to])/2, y-diagramFontSize/2-3, e.label)
+		y += messageGap
+	}
+	bottom := y - messageGap/2
+	var lifelines svgWriter
+	for i, name := range d.nodes {
+		fmt.Fprintf(&lifelines.Builder, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#adb5bd" stroke-dasharray="4 4"/>`,
+			x[i], top, x[i], bottom)
+		lifelines.box(x[i], diagramMargin+diagramBoxHeight/2, widths[i], diagramBoxHeight, name)
+	}
+	lifelines.WriteString(w.String())
+	width := x[n-1] + math.Max(widths[n-1]/2, extra) + diagramMargin
+	return lifelines.svg(width, bottom+diagramMargin, d.title())
+}
+
+// dashArray returns the SVG attribute for a dashed line, or an empty string
+func dashArray(dashed bool) string {
+	if dashed {
+		return ` stroke-dasharray="6 4"`
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package clickableai
+
+// The kinds of rows in a side-by-side diff
+const (
+	DiffSame    = "same"
+	DiffRemoved = "removed"
+	DiffAdded   = "added"
+	DiffChanged = "changed"
+)
+
+// maxDiffCells is the largest number of line pairs that are compared when diffing two documents.
+// Larger documents are shown as entirely changed.
+const maxDiffCells = 4_000_000
+
+// DiffRow is a row in a side-by-side diff. The line numbers start at 1, and are 0 if the side is empty.
+type DiffRow struct {
+	Kind      string `json:"kind"`
+	Left      string `json:"left"`
+	Right     string `json:"right"`
+	LeftLine  int    `json:"left_line,omitempty"`
+	RightLine int    `json:"right_line,omitempty"`
+}
+
+// Diff compares two documents line by line, and returns the rows of a side-by-side diff.
+// Removed lines that are followed by added lines are shown next to each other as changed rows.
+func Diff(left, right string) []DiffRow {
+	a, b := splitLines(left), splitLines(right)
+	if left == "" {
+		a = nil
+	}
+	if right == "" {
+		b = nil
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	var lcs [][]int
+	if len(a)*len(b) <= maxDiffCells {
+		lcs = make([][]int, len(a)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+	}
+
+	var (
+		rows           []DiffRow
+		removed, added []int // the indexes of the pending removed and added lines
+		i, j           int
+	)
+	flush := func() {
+		for k := 0; k < len(removed) || k < len(added); k++ {
+			row := DiffRow{Kind: DiffChanged}
+			if k < len(removed) {
+				row.Left, row.LeftLine = a[removed[k]], removed[k]+1
+			} else {
+				row.Kind = DiffAdded
+			}
+			if k < len(added) {
+				row.Right, row.RightLine = b[added[k]], added[k]+1
+			} else {
+				row.Kind = DiffRemoved
+			}
+			rows = append(rows, row)
+		}
+		removed, added = removed[:0], added[:0]
+	}
+	for i < len(a) || j < len(b) {
+		switch {
+		case lcs != nil && i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			rows = append(rows, DiffRow{Kind: DiffSame, Left: a[i], Right: b[j], LeftLine: i + 1, RightLine: j + 1})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs == nil || lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, i)
+			i++
+		default:
+			added = append(added, j)
+			j++
+		}
+	}
+	flush()
+	return rows
+}
+
+// diffStats returns the number of added and removed lines in a diff, where a changed row counts as both
+func diffStats(rows []DiffRow) (added, removed int) {
+	for _, row := range rows {
+		if row.Kind == DiffAdded || row.Kind == DiffChanged {
+			added++
+		}
+		if row.Kind == DiffRemoved || row.Kind == DiffChanged {
+			removed++
+		}
+	}
+	return added, removed
+}
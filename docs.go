@@ -0,0 +1,238 @@
+package clickableai
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DocsExtensions are the file extensions of the documentation files that are ingested
+var DocsExtensions = []string{".md", ".markdown", ".txt", ".html", ".htm"}
+
+// Passage is a chunk of a documentation file
+type Passage struct {
+	Source  string  `json:"source"`  // the path of the file, relative to the documentation directory
+	Heading string  `json:"heading"` // the closest heading above the passage, or the file name
+	Text    string  `json:"text"`
+	Score   float64 `json:"score,omitempty"`
+}
+
+// DocsIndex is a concurrency-safe index of passages from a local documentation corpus.
+// If Embedder is set, the passages are also embedded, and retrieved by both words and meaning.
+type DocsIndex struct {
+	Embedder  Embedder
+	ChunkSize int // the maximum number of bytes in a passage
+
+	mu       sync.RWMutex
+	passages map[string]Passage
+	terms    *termIndex
+	vectors  map[string][]float64
+}
+
+// NewDocsIndex creates a new and empty DocsIndex. The Embedder may be nil.
+func NewDocsIndex(e Embedder) *DocsIndex {
+	return &DocsIndex{
+		Embedder:  e,
+		ChunkSize: 1000,
+		passages:  make(map[string]Passage),
+		terms:     newTermIndex(),
+		vectors:   make(map[string][]float64),
+	}
+}
+
+// Len returns the number of indexed passages
+func (di *DocsIndex) Len() int {
+	di.mu.RLock()
+	defer di.mu.RUnlock()
+	return len(di.passages)
+}
+
+// AddDir ingests all documentation files in the given directory and its subdirectories.
+// Returns the number of files that were ingested.
+func (di *DocsIndex) AddDir(ctx context.Context, dir string) (int, error) {
+	count := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !contains(DocsExtensions, strings.ToLower(filepath.Ext(path))) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if err := di.AddFile(ctx, filepath.ToSlash(rel), string(data)); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// AddFile chunks and indexes the contents of a documentation file. The kind of file is given
+// by the extension of the source path. Passages from the same source are replaced.
+func (di *DocsIndex) AddFile(ctx context.Context, source, contents string) error {
+	switch strings.ToLower(filepath.Ext(source)) {
+	case ".html", ".htm":
+		contents = htmlToMarkdown(contents)
+	}
+	passages := chunkDocument(source, contents, di.ChunkSize)
+	var vectors [][]float64
+	if di.Embedder != nil && len(passages) > 0 {
+		texts := make([]string, len(passages))
+		for i, p := range passages {
+			texts[i] = p.Heading + "\n" + p.Text
+		}
+		var err error
+		if vectors, err = di.Embedder.Embed(ctx, texts); err != nil {
+			return fmt.Errorf("could not embed %s: %v", source, err)
+		}
+	}
+
+	di.mu.Lock()
+	defer di.mu.Unlock()
+	di.remove(source)
+	for i, p := range passages {
+		key := fmt.Sprintf("%s#%d", source, i)
+		di.passages[key] = p
+		di.terms.add(key, weightedTerms(p.Heading, p.Text, titleWeight))
+		if vectors != nil {
+			di.vectors[key] = vectors[i]
+		}
+	}
+	return nil
+}
+
+// remove removes all passages from the given source. The caller must hold the write lock.
+func (di *DocsIndex) remove(source string) {
+	for key, p := range di.passages {
+		if p.Source == source {
+			delete(di.passages, key)
+			delete(di.vectors, key)
+			di.terms.remove(key)
+		}
+	}
+}
+
+// Retrieve returns up to n passages that match the query, with the best matches first
+func (di *DocsIndex) Retrieve(ctx context.Context, query string, n int) []Passage {
+	queryVector := embedQuery(ctx, di.Embedder, query)
+
+	di.mu.RLock()
+	defer di.mu.RUnlock()
+
+	scores := hybridScores(di.terms.scores(searchTerms(query)), queryVector, di.vectors)
+	var passages []Passage
+	for _, key := range rankedKeys(scores, n) {
+		p := di.passages[key]
+		p.Score = scores[key]
+		passages = append(passages, p)
+	}
+	return passages
+}
+
+// markdownHeadingRegexp matches a Markdown heading
+var markdownHeadingRegexp = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+
+// chunkDocument splits a Markdown or text document into passages of whole paragraphs, of up to
+// chunkSize bytes. Paragraphs that are longer than chunkSize are split at word boundaries.
+func chunkDocument(source, contents string, chunkSize int) []Passage {
+	heading := strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	var (
+		passages []Passage
+		current  strings.Builder
+	)
+	flush := func() {
+		if text := strings.TrimSpace(current.String()); text != "" {
+			passages = append(passages, Passage{Source: source, Heading: heading, Text: text})
+		}
+		current.Reset()
+	}
+	for _, paragraph := range strings.Split(strings.ReplaceAll(contents, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if m := markdownHeadingRegexp.FindStringSubmatch(strings.SplitN(paragraph, "\n", 2)[0]); m != nil {
+			// A new section starts a new passage
+			flush()
+			heading = strings.TrimSpace(m[1])
+		}
+		for len(paragraph) > chunkSize {
+			flush()
+			cut := strings.LastIndexAny(truncate(paragraph, chunkSize), " \n")
+			if cut <= 0 {
+				cut = len(truncate(paragraph, chunkSize))
+			}
+			current.WriteString(paragraph[:cut])
+			flush()
+			paragraph = strings.TrimSpace(paragraph[cut:])
+		}
+		if current.Len() > 0 && current.Len()+len(paragraph)+2 > chunkSize {
+			flush()
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(paragraph)
+	}
+	flush()
+	return passages
+}
+
+var (
+	htmlIgnoredRegexp   = regexp.MustCompile(`(?is)<(script|style|head|nav|footer)\b.*?</(script|style|head|nav|footer)>|<!--.*?-->`)
+	htmlHeadingRegexp   = regexp.MustCompile(`(?is)<h([1-6])\b[^>]*>(.*?)</h[1-6]>`)
+	htmlParagraphRegexp = regexp.MustCompile(`(?i)</?(p|div|section|article|pre|ul|ol|table|tr|blockquote)\b[^>]*>|<br\s*/?>`)
+	htmlListItemRegexp  = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	htmlTagRegexp       = regexp.MustCompile(`<[^>]*>`)
+	blankLinesRegexp    = regexp.MustCompile(`\n\s*\n\s*`)
+)
+
+// htmlToMarkdown turns an HTML document into text with Markdown headings and paragraphs
+func htmlToMarkdown(document string) string {
+	text := htmlIgnoredRegexp.ReplaceAllString(document, "")
+	text = htmlHeadingRegexp.ReplaceAllStringFunc(text, func(h string) string {
+		m := htmlHeadingRegexp.FindStringSubmatch(h)
+		title := strings.TrimSpace(htmlTagRegexp.ReplaceAllString(m[2], ""))
+		return "\n\n" + strings.Repeat("#", int(m[1][0]-'0')) + " " + title + "\n\n"
+	})
+	text = htmlParagraphRegexp.ReplaceAllString(text, "\n\n")
+	text = htmlListItemRegexp.ReplaceAllString(text, "\n* ")
+	text = htmlTagRegexp.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(blankLinesRegexp.ReplaceAllString(text, "\n\n"))
+}
+
+// docsPrompt returns the start of a prompt that gives the passages to the backend
+func docsPrompt(passages []Passage) string {
+	var sb strings.Builder
+	sb.WriteString("Use the following passages from the documentation where they are relevant, and prefer them over what you remember. Refer to them as [1], [2] and so on.\n")
+	for i, p := range passages {
+		fmt.Fprintf(&sb, "\n[%d] %s (%s):\n%s\n", i+1, p.Heading, p.Source, p.Text)
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// sourcesMarkdown returns a Markdown section that lists the passages as sources
+func sourcesMarkdown(title string, passages []Passage) string {
+	var sb strings.Builder
+	sb.WriteString("\n\n## " + title + "\n\n")
+	for i, p := range passages {
+		fmt.Fprintf(&sb, "%d. %s (`%s`)\n", i+1, p.Heading, p.Source)
+	}
+	return sb.String()
+}
@@ -0,0 +1,181 @@
+package clickableai
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/xyproto/ollamaclient/v2"
+)
+
+// Embedder turns texts into vectors, where texts with similar meanings give similar vectors
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// EmbeddingBackends are the backends that can be used for embeddings.
+// An empty name gives the LexicalEmbedder.
+var EmbeddingBackends = []string{"", "ollama", "openai"}
+
+var errEmptyEmbedding = errors.New("the embedding is empty")
+
+// OllamaEmbedder is an Embedder that uses the embeddings API of an Ollama server
+type OllamaEmbedder struct {
+	Config *ollamaclient.Config
+	Model  string
+}
+
+// Embed returns one embedding vector per text
+func (e *OllamaEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	oc := *e.Config
+	oc.ModelName = e.Model
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		v, err := oc.Embeddings(text)
+		if err != nil {
+			return nil, err
+		}
+		if len(v) == 0 {
+			return nil, errEmptyEmbedding
+		}
+		vectors = append(vectors, v)
+	}
+	return vectors, nil
+}
+
+// OpenAIEmbedder is an Embedder that uses the /embeddings endpoint of an OpenAI-compatible server
+type OpenAIEmbedder struct {
+	Client *OpenAI
+	Model  string
+}
+
+// Embed returns one embedding vector per text
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	return e.Client.Embed(ctx, e.Model, texts)
+}
+
+// lexicalDimensions is the number of dimensions of the vectors from LexicalEmbedder
+const lexicalDimensions = 1024
+
+// LexicalEmbedder is an Embedder that needs no backend. The vectors are hashed character
+// trigrams of the words, after lowercasing and removing plural endings and spaces, so that
+// "Goroutines", "goroutine" and "Go routines" give the same vector.
+type LexicalEmbedder struct{}
+
+// Embed returns one vector per text
+func (LexicalEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = lexicalVector(text)
+	}
+	return vectors, nil
+}
+
+// lexicalVector returns the hashed character trigrams of the normalized text
+func lexicalVector(text string) []float64 {
+	v := make([]float64, lexicalDimensions)
+	runes := []rune("^" + lexicalNormalize(text) + "$")
+	for i := 0; i+3 <= len(runes); i++ {
+		h := fnv.New32a()
+		h.Write([]byte(string(runes[i : i+3])))
+		v[h.Sum32()%lexicalDimensions]++
+	}
+	return v
+}
+
+// lexicalNormalize lowercases the text, removes plural endings from the words and joins them
+func lexicalNormalize(text string) string {
+	words := splitWords(text)
+	for i, word := range words {
+		words[i] = normalizeWord(word)
+	}
+	return strings.Join(words, "")
+}
+
+// splitWords splits the text into words of letters and digits
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// normalizeWord lowercases the word and removes a plural ending
+func normalizeWord(word string) string {
+	word = strings.ToLower(word)
+	if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// fallbackEmbedder uses the LexicalEmbedder instead of the primary Embedder, if the primary
+// Embedder fails the first time it is used. The choice is only made once, so that vectors of
+// different dimensions from the two Embedders are never mixed.
+type fallbackEmbedder struct {
+	primary Embedder
+
+	mu     sync.Mutex
+	chosen Embedder // nil until the primary Embedder has been tried
+}
+
+// Embed returns one embedding vector per text
+func (e *fallbackEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	e.mu.Lock()
+	if e.chosen != nil {
+		chosen := e.chosen
+		e.mu.Unlock()
+		return chosen.Embed(ctx, texts)
+	}
+	defer e.mu.Unlock()
+	vectors, err := e.primary.Embed(ctx, texts)
+	if err == nil {
+		e.chosen = e.primary
+		return vectors, nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+	log.Println("Error: could not get embeddings, using lexical similarity instead:", err)
+	e.chosen = LexicalEmbedder{}
+	return e.chosen.Embed(ctx, texts)
+}
+
+// NewEmbedder creates the Embedder that is configured by the embeddings setting.
+// If no embeddings backend is given, or if it fails the first time, the LexicalEmbedder is used.
+func (c *Config) NewEmbedder() Embedder {
+	switch c.Embeddings {
+	case "ollama":
+		oc := ollamaclient.New(c.EmbeddingModel)
+		oc.ServerAddr = c.OllamaHost
+		return &fallbackEmbedder{primary: &OllamaEmbedder{Config: oc, Model: c.EmbeddingModel}}
+	case "openai":
+		return &fallbackEmbedder{primary: &OpenAIEmbedder{Client: NewOpenAI(c.OpenAIBaseURL, c.OpenAIModel), Model: c.EmbeddingModel}}
+	}
+	return LexicalEmbedder{}
+}
+
+// CosineSimilarity returns the cosine similarity of two vectors, or 0 if one of them is zero
+// or if they have different lengths
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
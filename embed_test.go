@@ -0,0 +1,37 @@
+package clickableai
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// embedderFunc is an Embedder that calls a function
+type embedderFunc func(ctx context.Context, texts []string) ([][]float64, error)
+
+func (f embedderFunc) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	return f(ctx, texts)
+}
+
+func TestFallbackEmbedderChoosesOnce(t *testing.T) {
+	fail := true
+	primary := embedderFunc(func(ctx context.Context, texts []string) ([][]float64, error) {
+		if fail {
+			return nil, errors.New("the embeddings backend is down")
+		}
+		return [][]float64{{1, 2, 3}}, nil
+	})
+	e := &fallbackEmbedder{primary: primary}
+	first, err := e.Embed(context.Background(), []string{"Go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fail = false
+	second, err := e.Embed(context.Background(), []string{"Go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first[0]) != len(second[0]) {
+		t.Errorf("the vectors have %d and %d dimensions", len(first[0]), len(second[0]))
+	}
+}
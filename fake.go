@@ -0,0 +1,195 @@
+package clickableai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"text/template"
+)
+
+// DefaultFakeTemplates are the Markdown templates used by NewFake
+var DefaultFakeTemplates = []string{
+	"# {{.Subject}}\n\n{{.Subject}} is a technical topic that is often mentioned together with {{.Related}}.\n\n## Overview\n\n* It has a well defined purpose.\n* It is used in practice.\n\n```go\nexample({{printf \"%q\" .Subject}})\n```\n",
+	"# {{.Subject}}\n\n## Introduction\n\nThis page describes {{.Subject}}.\n\n## Details\n\n1. The first important aspect.\n2. The relation to {{.Related}}.\n",
+	"# {{.Subject}}\n\n{{.Subject}} takes input from {{.Related}}.\n\n```diagram\nflowchart\n{{.Related}} -> {{.Subject}}: input\n{{.Subject}} -> Result\nResult --> {{.Related}}: feedback\n```\n",
+	"# {{.Subject}}\n\n> {{.Subject}} builds upon {{.Related}}.\n\n| Property | Value |\n|----------|-------|\n| Name     | {{.Subject}} |\n| Related  | {{.Related}} |\n",
+}
+
+// DefaultFakeTopics is the pool of topics that the Fake generator picks from
+var DefaultFakeTopics = []string{
+	"Algorithms", "Caching", "Compilers", "Concurrency", "Databases", "Garbage Collection",
+	"Hashing", "Kubernetes", "Linkers", "Memory", "Networking", "Parsing", "Profiling",
+	"Scheduling", "Security", "Testing", "Type Systems", "Virtual Machines",
+}
+
+// Fake is a deterministic Generator that needs no network access.
+// The same seed and prompt always give the same output.
+type Fake struct {
+	Seed      int64
+	Templates []string
+	Topics    []string
+	NumTopics int
+}
+
+// fakeData is the data that is given to the Markdown templates
+type fakeData struct {
+	Subject string
+	Related string
+}
+
+// NewFake creates a new Fake generator with the default templates and topics
+func NewFake(seed int64) *Fake {
+	return &Fake{
+		Seed:      seed,
+		Templates: DefaultFakeTemplates,
+		Topics:    DefaultFakeTopics,
+		NumTopics: 10,
+	}
+}
+
+// Name returns the name of this backend
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+// Generate returns deterministic Markdown, or a JSON object with topics if req.JSON is set.
+// For an image, the title is given by a hash of the image data.
+func (f *Fake) Generate(ctx context.Context, req Request) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	rng := f.rand(req.Prompt)
+	if len(req.Images) > 0 && strings.HasPrefix(req.Prompt, imageTitlePrompt) {
+		return f.imageTitle(req.Images[0]), nil
+	}
+	if req.JSON && strings.HasSuffix(req.Prompt, reviewJSONPrompt) {
+		return f.review(rng, req.Prompt)
+	}
+	if req.JSON {
+		var topics []Topic
+		for _, name := range f.pickTopics(rng) {
+			topics = append(topics, Topic{
+				Name:        name,
+				Description: "More about " + name + ".",
+				Relation:    Relations[rng.Intn(len(Relations))],
+				Confidence:  float64(5+rng.Intn(5)) / 10,
+			})
+		}
+		data, err := json.Marshal(map[string][]Topic{"topics": topics})
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	if len(f.Templates) == 0 {
+		return "# " + promptSubject(req.Prompt) + "\n", nil
+	}
+	if req.Temperature > 0 {
+		// Other temperatures give other pages, like when regenerating a page with a real backend
+		rng = f.rand(fmt.Sprintf("%s\x00%g", req.Prompt, req.Temperature))
+	}
+	t, err := template.New("fake").Parse(f.Templates[rng.Intn(len(f.Templates))])
+	if err != nil {
+		return "", err
+	}
+	related := "Computers"
+	if len(f.Topics) > 0 {
+		related = f.Topics[rng.Intn(len(f.Topics))]
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, fakeData{Subject: promptSubject(req.Prompt), Related: related}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// GenerateStream delivers the output of Generate word by word
+func (f *Fake) GenerateStream(ctx context.Context, req Request, callback func(chunk string)) error {
+	output, err := f.Generate(ctx, req)
+	if err != nil {
+		return err
+	}
+	for _, word := range strings.SplitAfter(output, " ") {
+		callback(word)
+	}
+	return nil
+}
+
+// review returns a JSON object with up to three claims, which are sentences from the page in the prompt.
+// One of the claims is marked as uncertain, and the first source is cited if there are sources.
+func (f *Fake) review(rng *rand.Rand, prompt string) (string, error) {
+	page := prompt
+	if pos := strings.LastIndex(page, "The page:"); pos != -1 {
+		page = page[pos+len("The page:"):]
+	}
+	var claims []Claim
+	for _, line := range strings.Split(page, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasSuffix(line, ".") && !strings.HasPrefix(line, "#") && len(claims) < 3 {
+			claims = append(claims, Claim{Text: line})
+		}
+	}
+	if len(claims) > 0 {
+		i := rng.Intn(len(claims))
+		claims[i].Uncertain = true
+		claims[i].Reason = "This is a fake review."
+		if strings.Contains(prompt, "\nSources:\n") {
+			claims[0].Sources = []int{1}
+		}
+	}
+	data, err := json.Marshal(map[string][]Claim{"claims": claims})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// imageTitle returns a title that is given by a hash of the image data
+func (f *Fake) imageTitle(img Image) string {
+	h := fnv.New32a()
+	h.Write(img.Data)
+	return fmt.Sprintf("Image %08x", h.Sum32())
+}
+
+// rand returns a random number generator that is seeded by both the seed and the prompt
+func (f *Fake) rand(prompt string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(prompt))
+	return rand.New(rand.NewSource(f.Seed ^ int64(h.Sum64())))
+}
+
+// pickTopics picks NumTopics distinct topics from the topic pool
+func (f *Fake) pickTopics(rng *rand.Rand) []string {
+	n := f.NumTopics
+	if n <= 0 || n > len(f.Topics) {
+		n = len(f.Topics)
+	}
+	topics := make([]string, 0, n)
+	for _, i := range rng.Perm(len(f.Topics))[:n] {
+		topics = append(topics, f.Topics[i])
+	}
+	return topics
+}
+
+// promptSubject tries to find the subject of a prompt, which is the last keyword in the trail
+func promptSubject(prompt string) string {
+	subject := prompt
+	if pos := strings.LastIndex(subject, ":"); pos != -1 {
+		subject = subject[pos+1:]
+	}
+	if pos := strings.LastIndex(subject, "->"); pos != -1 {
+		subject = subject[pos+2:]
+	}
+	if pos := strings.IndexAny(subject, ".|\n"); pos != -1 {
+		subject = subject[:pos]
+	}
+	subject = shortenToTwoWords(strings.TrimSpace(subject))
+	if subject == "" {
+		return "Untitled"
+	}
+	return subject
+}
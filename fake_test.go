@@ -0,0 +1,83 @@
+package clickableai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFakeIsDeterministic(t *testing.T) {
+	ctx := context.Background()
+	for _, req := range []Request{
+		{Prompt: "Explain this: Go -> Concurrency"},
+		{Prompt: "Explain this: Go -> Concurrency", Temperature: 0.7},
+		{Prompt: "Topics for Go. " + TopicsJSONPrompt, JSON: true},
+	} {
+		first, err := NewFake(42).Generate(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := NewFake(42).Generate(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first != second {
+			t.Errorf("the same seed and request gave different output:\n%s\n%s", first, second)
+		}
+	}
+}
+
+func TestFakeSeedsDiffer(t *testing.T) {
+	ctx := context.Background()
+	req := Request{Prompt: "Topics for Go. " + TopicsJSONPrompt, JSON: true}
+	outputs := map[string]bool{}
+	for seed := int64(1); seed <= 5; seed++ {
+		output, err := NewFake(seed).Generate(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs[output] = true
+	}
+	if len(outputs) < 2 {
+		t.Error("five seeds gave the same topics")
+	}
+}
+
+func TestFakeTopicsAreJSON(t *testing.T) {
+	output, err := NewFake(1).Generate(context.Background(), Request{Prompt: "Topics for Go. " + TopicsJSONPrompt, JSON: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	topics, err := ParseTopicsJSON(output)
+	if err != nil {
+		t.Fatalf("could not parse %q: %v", output, err)
+	}
+	if len(topics) == 0 {
+		t.Error("no topics")
+	}
+}
+
+func TestFakeStreamMatchesGenerate(t *testing.T) {
+	ctx := context.Background()
+	req := Request{Prompt: "Explain this: Rust"}
+	f := NewFake(7)
+	output, err := f.Generate(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sb strings.Builder
+	if err := f.GenerateStream(ctx, req, func(chunk string) { sb.WriteString(chunk) }); err != nil {
+		t.Fatal(err)
+	}
+	if sb.String() != output {
+		t.Errorf("the streamed output differs:\n%s\n%s", sb.String(), output)
+	}
+}
+
+func TestFakeCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewFake(1).Generate(ctx, Request{Prompt: "Go"}); err == nil {
+		t.Error("a canceled context gave no error")
+	}
+}
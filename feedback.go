@@ -0,0 +1,283 @@
+package clickableai
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxFeedback is the maximum number of feedback entries that are kept per page
+	maxFeedback = 200
+	// maxCommentLength is the maximum number of runes in a feedback comment
+	maxCommentLength = 500
+	// maxFeedbackSize is the maximum number of bytes in a feedback request
+	maxFeedbackSize = 64 * 1024
+	// autoRegenerateStep is how much higher the temperature is when a badly rated page is regenerated
+	autoRegenerateStep = 0.5
+)
+
+// Feedback is a thumbs up or down, with an optional comment, on a page or on one of its suggested topics.
+// The number of the page version, the version of the prompts and the backend are stored with it.
+// Every user or session has at most one rating per version of a page, and per topic.
+type Feedback struct {
+	Topic   string    `json:"topic,omitempty"` // the suggested topic, or empty for the page itself
+	Rating  int       `json:"rating"`          // 1 for thumbs up and -1 for thumbs down
+	Comment string    `json:"comment,omitempty"`
+	Version int       `json:"version"`
+	Prompt  string    `json:"prompt,omitempty"`
+	Backend string    `json:"backend,omitempty"`
+	Created time.Time `json:"created"`
+	Owner   string    `json:"-"` // the user or session that gave the feedback, as returned by Server.owner
+}
+
+// FeedbackScore is the sum of the ratings, together with the number of thumbs up and down
+type FeedbackScore struct {
+	Score int `json:"score"`
+	Up    int `json:"up"`
+	Down  int `json:"down"`
+}
+
+// add adds a rating to the score
+func (fs *FeedbackScore) add(rating int) {
+	fs.Score += rating
+	if rating > 0 {
+		fs.Up++
+	} else {
+		fs.Down++
+	}
+}
+
+// TopicScore is the score of a suggested topic
+type TopicScore struct {
+	Topic string `json:"topic"`
+	FeedbackScore
+}
+
+// PageReport is a page in the report of the worst-rated pages
+type PageReport struct {
+	Title   string `json:"title"`
+	URL     string `json:"url"` // opens the page and its trail
+	Lang    string `json:"lang"`
+	Model   string `json:"model,omitempty"`
+	Version int    `json:"version"`
+	Pinned  int    `json:"pinned,omitempty"`
+	Backend string `json:"backend,omitempty"`
+	Prompt  string `json:"prompt,omitempty"`
+	FeedbackScore
+	Comments []Feedback   `json:"comments,omitempty"` // the feedback with comments, newest first
+	Topics   []TopicScore `json:"topics,omitempty"`   // the topics with feedback, worst first
+}
+
+// Score returns the score of the given version of the page, from the feedback on the page itself
+func (p Page) Score(version int) FeedbackScore {
+	var score FeedbackScore
+	for _, f := range p.Feedback {
+		if f.Topic == "" && f.Version == version {
+			score.add(f.Rating)
+		}
+	}
+	return score
+}
+
+// TopicScore returns the score of the given suggested topic of the page, for all versions
+func (p Page) TopicScore(topic string) FeedbackScore {
+	var score FeedbackScore
+	for _, f := range p.Feedback {
+		if f.Topic == topic {
+			score.add(f.Rating)
+		}
+	}
+	return score
+}
+
+// FeedbackReport returns up to limit pages that have feedback, with the lowest scores for their
+// canonical versions first. Pages with the same score are ordered by the number of thumbs down.
+func FeedbackReport(pages []Page, limit int) []PageReport {
+	var reports []PageReport
+	for _, page := range pages {
+		if len(page.Feedback) == 0 {
+			continue
+		}
+		v, _ := page.FindVersion(page.Version)
+		report := PageReport{
+			Title:         strings.Join(page.Trail, " → "),
+			URL:           Permalink(page.PageID),
+			Lang:          page.Lang,
+			Model:         page.Model,
+			Version:       page.Version,
+			Pinned:        page.Pinned,
+			Backend:       v.Backend,
+			Prompt:        v.Prompt,
+			FeedbackScore: page.Score(page.Version),
+		}
+		topics := make(map[string]*TopicScore)
+		for i := len(page.Feedback) - 1; i >= 0; i-- {
+			f := page.Feedback[i]
+			if f.Topic == "" {
+				if f.Comment != "" {
+					report.Comments = append(report.Comments, f)
+				}
+				continue
+			}
+			if topics[f.Topic] == nil {
+				topics[f.Topic] = &TopicScore{Topic: f.Topic}
+			}
+			topics[f.Topic].add(f.Rating)
+		}
+		for _, ts := range topics {
+			report.Topics = append(report.Topics, *ts)
+		}
+		sort.Slice(report.Topics, func(i, j int) bool {
+			a, b := report.Topics[i], report.Topics[j]
+			if a.Score != b.Score {
+				return a.Score < b.Score
+			}
+			return a.Topic < b.Topic
+		})
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		a, b := reports[i], reports[j]
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		if a.Down != b.Down {
+			return a.Down > b.Down
+		}
+		return a.Title < b.Title
+	})
+	if limit > 0 && len(reports) > limit {
+		reports = reports[:limit]
+	}
+	return reports
+}
+
+// feedbackHandler stores a thumbs "up" or "down" in the "rating" field, with an optional "comment",
+// on the given "version" of a page, or on one of its suggested topics if a "topic" is given.
+// The user or session must be known, and a new rating replaces the previous one of the same owner.
+// Returns the new score of the page version or the topic.
+func (s *Server) feedbackHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Error: Use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxFeedbackSize)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	owner := s.owner(w, r, false)
+	if owner == "" {
+		http.Error(w, "Error: A session is needed to give feedback", http.StatusForbidden)
+		return
+	}
+	id := s.pageID(r)
+	page, ok := s.cachedPage(w, id)
+	if !ok {
+		return
+	}
+	f := Feedback{
+		Topic:   strings.TrimSpace(r.FormValue("topic")),
+		Comment: strings.TrimSpace(r.FormValue("comment")),
+		Version: page.Version,
+		Owner:   owner,
+	}
+	switch r.FormValue("rating") {
+	case "up":
+		f.Rating = 1
+	case "down":
+		f.Rating = -1
+	default:
+		http.Error(w, "Error: The rating must be up or down", http.StatusBadRequest)
+		return
+	}
+	if utf8.RuneCountInString(f.Comment) > maxCommentLength {
+		http.Error(w, fmt.Sprintf("Error: The comment is too long (the maximum is %d characters)", maxCommentLength), http.StatusBadRequest)
+		return
+	}
+	if value := r.FormValue("version"); value != "" {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Error: Invalid version", http.StatusBadRequest)
+			return
+		}
+		f.Version = number
+	}
+	v, ok := page.FindVersion(f.Version)
+	if !ok {
+		http.Error(w, "Error: No such version", http.StatusNotFound)
+		return
+	}
+	f.Prompt = v.Prompt
+	f.Backend = v.Backend
+	if page, ok = s.Cache.AddFeedback(id, f); !ok {
+		http.Error(w, "Error: The page has not been generated", http.StatusNotFound)
+		return
+	}
+	s.regenerateIfBad(id, page)
+	if f.Topic != "" {
+		writeJSON(w, page.TopicScore(f.Topic))
+		return
+	}
+	writeJSON(w, page.Score(f.Version))
+}
+
+// regenerateIfBad regenerates a page in the background, with a higher temperature, if the score of
+// its canonical version has fallen to RegenerateScore. Pages with a pinned version are kept as they are.
+func (s *Server) regenerateIfBad(id PageID, page Page) {
+	score := page.Score(page.Version).Score
+	if s.RegenerateScore >= 0 || page.Pinned != 0 || score > s.RegenerateScore {
+		return
+	}
+	key := id.Key()
+	if _, busy := s.regenerating.LoadOrStore(key, true); busy {
+		return
+	}
+	go func() {
+		defer s.regenerating.Delete(key)
+		ctx := context.Background()
+		temperature := min(s.MainTemperature+autoRegenerateStep, 2)
+		resp, sources, err := s.generateMarkdown(ctx, id, "", "", temperature, nil)
+		if err != nil {
+			log.Println("Error:", err)
+			return
+		}
+		log.Printf("Regenerated %s, since the score of version %d was %d\n", strings.Join(id.Trail, " -> "), page.Version, score)
+		s.storePage(ctx, id, Version{
+			Kind:        VersionRegenerated,
+			Markdown:    resp.Text,
+			Backend:     resp.Backend,
+			Temperature: temperature,
+			Sources:     sources,
+		})
+	}()
+}
+
+// feedbackReportHandler shows the worst-rated pages to admins
+func (s *Server) feedbackReportHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	if !s.checkAdmin(w, r) {
+		return
+	}
+	s.render(w, r, s.reportTmpl, PageData{Report: FeedbackReport(s.Cache.Pages(), searchLimit(r))})
+}
+
+// feedbackReportAPIHandler returns the worst-rated pages as JSON, to admins
+func (s *Server) feedbackReportAPIHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	if !s.checkAdmin(w, r) {
+		return
+	}
+	reports := FeedbackReport(s.Cache.Pages(), searchLimit(r))
+	if reports == nil {
+		reports = []PageReport{}
+	}
+	writeJSON(w, map[string]any{"pages": reports})
+}
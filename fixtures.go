@@ -0,0 +1,114 @@
+package clickableai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrNoFixture is returned by Replayer when no fixture has been recorded for a request
+var ErrNoFixture = errors.New("no recorded fixture for this request")
+
+// Fixture is a recorded request and response pair
+type Fixture struct {
+	Backend  string  `json:"backend"`
+	Request  Request `json:"request"`
+	Response string  `json:"response"`
+}
+
+// FixtureKey returns the file name that is used for storing the fixture for the given request
+func FixtureKey(req Request) string {
+	data, _ := json.Marshal(req)
+	return fmt.Sprintf("%x.json", sha256.Sum256(data))
+}
+
+// Recorder is a Generator that wraps another Generator and saves every
+// request and response pair as a fixture file in Dir
+type Recorder struct {
+	Generator Generator
+	Dir       string
+}
+
+// NewRecorder creates a new Recorder that records the output of g into dir
+func NewRecorder(g Generator, dir string) *Recorder {
+	return &Recorder{Generator: g, Dir: dir}
+}
+
+// Name returns the name of the wrapped backend
+func (rec *Recorder) Name() string {
+	return rec.Generator.Name()
+}
+
+// Generate calls the wrapped Generator and records the result
+func (rec *Recorder) Generate(ctx context.Context, req Request) (string, error) {
+	output, err := rec.Generator.Generate(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if err := rec.save(Fixture{Backend: rec.Generator.Name(), Request: req, Response: output}); err != nil {
+		return "", fmt.Errorf("could not record fixture: %v", err)
+	}
+	return output, nil
+}
+
+// GenerateStream streams from the wrapped Generator, if it can, and records the result
+func (rec *Recorder) GenerateStream(ctx context.Context, req Request, callback func(chunk string)) error {
+	resp, err := GenerateResponseStream(ctx, rec.Generator, req, callback)
+	if err != nil {
+		return err
+	}
+	if err := rec.save(Fixture{Backend: rec.Generator.Name(), Request: req, Response: resp.Text}); err != nil {
+		return fmt.Errorf("could not record fixture: %v", err)
+	}
+	return nil
+}
+
+// save writes the given fixture to Dir
+func (rec *Recorder) save(fixture Fixture) error {
+	if err := os.MkdirAll(rec.Dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(rec.Dir, FixtureKey(fixture.Request)), data, 0o644)
+}
+
+// Replayer is a Generator that serves responses from fixture files in Dir,
+// without contacting any backend
+type Replayer struct {
+	Dir string
+}
+
+// NewReplayer creates a new Replayer that replays the fixtures in dir
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{Dir: dir}
+}
+
+// Name returns the name of this backend
+func (rep *Replayer) Name() string {
+	return "replay"
+}
+
+// Generate returns the recorded response for the given request, or ErrNoFixture
+func (rep *Replayer) Generate(ctx context.Context, req Request) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filepath.Join(rep.Dir, FixtureKey(req)))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoFixture
+	} else if err != nil {
+		return "", err
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return "", fmt.Errorf("could not parse fixture: %v", err)
+	}
+	return fixture.Response, nil
+}
@@ -0,0 +1,65 @@
+package clickableai
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	requests := []Request{
+		{Prompt: "Explain this: Go"},
+		{Prompt: "Explain this: Go", Temperature: 0.5},
+		{Prompt: "Topics for Go. " + TopicsJSONPrompt, JSON: true, Topics: true},
+	}
+
+	recorder := NewRecorder(NewFake(3), dir)
+	recorded := make([]string, len(requests))
+	for i, req := range requests {
+		output, err := recorder.Generate(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recorded[i] = output
+		if _, err := os.Stat(filepath.Join(dir, FixtureKey(req))); err != nil {
+			t.Errorf("no fixture was written for request %d: %v", i, err)
+		}
+	}
+	if recorder.Name() != "fake" {
+		t.Errorf("Name = %q, want the name of the recorded backend", recorder.Name())
+	}
+
+	replayer := NewReplayer(dir)
+	for i, req := range requests {
+		output, err := replayer.Generate(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output != recorded[i] {
+			t.Errorf("request %d was replayed as %q, want %q", i, output, recorded[i])
+		}
+	}
+	if _, err := replayer.Generate(ctx, Request{Prompt: "Explain this: Rust"}); !errors.Is(err, ErrNoFixture) {
+		t.Errorf("a request that was not recorded gave %v, want ErrNoFixture", err)
+	}
+}
+
+func TestWrapFixtures(t *testing.T) {
+	f := NewFake(1)
+	for mode, want := range map[string]string{"": "fake", "record": "fake", "replay": "replay"} {
+		g, err := WrapFixtures(f, t.TempDir(), mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Name() != want {
+			t.Errorf("mode %q gave %q, want %q", mode, g.Name(), want)
+		}
+	}
+	if _, err := WrapFixtures(f, t.TempDir(), "rewind"); err == nil {
+		t.Error("an unknown mode gave no error")
+	}
+}
@@ -0,0 +1,116 @@
+package clickableai
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Request is a request for generated text
+type Request struct {
+	Prompt      string
+	Temperature float64
+	JSON        bool    // ask the backend for a JSON object instead of free text
+	Model       string  // optional, overrides the default model of the backend
+	Backend     string  // optional, selects a specific backend when routing
+	Topics      bool    `json:",omitempty"` // the prompt asks for topics, so a backend may use tool calling
+	Images      []Image `json:",omitempty"` // for multimodal backends
+}
+
+// Image is an image that is given to a multimodal backend
+type Image struct {
+	MIMEType string
+	Data     []byte
+}
+
+// Base64 returns the image data, base64 encoded
+func (img Image) Base64() string {
+	return base64.StdEncoding.EncodeToString(img.Data)
+}
+
+// Generator is a backend that can generate text from a prompt
+type Generator interface {
+	Name() string
+	Generate(ctx context.Context, req Request) (string, error)
+}
+
+// StreamGenerator is a Generator that can also deliver the output chunk by chunk
+type StreamGenerator interface {
+	Generator
+	GenerateStream(ctx context.Context, req Request, callback func(chunk string)) error
+}
+
+// TopicsJSONPrompt asks for topics as a JSON object that ParseTopicsJSON can parse
+const TopicsJSONPrompt = `Output a JSON object on the form {"topics": [{"name": "topic", "description": "one line about the topic", "relation": "prerequisite, deeper, related or alternative", "confidence": 0.8}]} and nothing else.`
+
+var errNoTopicsInJSON = errors.New("no topics found in the JSON output")
+
+// ParseTopicsJSON parses output on the form {"topics": [...]} or a plain JSON array.
+// The topics can be strings or objects, like the ones from the suggest_topics tool.
+func ParseTopicsJSON(output string) ([]Topic, error) {
+	output = trimJSONFence(output)
+
+	var topics []Topic
+	if strings.HasPrefix(output, "[") {
+		if err := json.Unmarshal([]byte(output), &topics); err != nil {
+			return nil, err
+		}
+	} else {
+		var obj struct {
+			Topics []Topic `json:"topics"`
+		}
+		if err := json.Unmarshal([]byte(output), &obj); err != nil {
+			return nil, err
+		}
+		topics = obj.Topics
+	}
+
+	var (
+		result []Topic
+		names  []string
+	)
+	for _, topic := range topics {
+		topic.normalize(SourceModel)
+		if topic.Name != "" && !contains(names, topic.Name) {
+			result = append(result, topic)
+			names = append(names, topic.Name)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errNoTopicsInJSON
+	}
+	return result, nil
+}
+
+// GenerateTopics asks the generator for topics in JSON mode.
+// If the output is not valid JSON, the topics are extracted from the text instead.
+func GenerateTopics(ctx context.Context, g Generator, prompt string, temperature float64, keywords []string) ([]Topic, error) {
+	output, err := g.Generate(ctx, Request{
+		Prompt:      prompt + " " + TopicsJSONPrompt,
+		Temperature: temperature,
+		JSON:        true,
+		Topics:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if topics, err := ParseTopicsJSON(output); err == nil {
+		return topics, nil
+	}
+	topics := NewTopics(ExtractAndShortenTopics(output, keywords), SourceModel)
+	for i := range topics {
+		topics[i].normalize(SourceModel)
+	}
+	return topics, nil
+}
+
+// trimJSONFence removes a ```json code fence around the output, if there is one
+func trimJSONFence(output string) string {
+	output = strings.TrimSpace(output)
+	output = strings.TrimPrefix(output, "```json")
+	output = strings.TrimPrefix(output, "```")
+	output = strings.TrimSuffix(output, "```")
+	return strings.TrimSpace(output)
+}
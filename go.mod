@@ -6,6 +6,7 @@ require (
 	github.com/xyproto/env/v2 v2.3.0
 	github.com/xyproto/ollamaclient/v2 v2.5.0
 	github.com/xyproto/simpleflash v1.0.1
+	golang.org/x/crypto v0.26.0
 )
 
 require (
@@ -34,7 +35,6 @@ require (
 	go.opentelemetry.io/otel v1.28.0 // indirect
 	go.opentelemetry.io/otel/metric v1.28.0 // indirect
 	go.opentelemetry.io/otel/trace v1.28.0 // indirect
-	golang.org/x/crypto v0.26.0 // indirect
 	golang.org/x/net v0.28.0 // indirect
 	golang.org/x/oauth2 v0.22.0 // indirect
 	golang.org/x/sync v0.8.0 // indirect
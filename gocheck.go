@@ -0,0 +1,290 @@
+package clickableai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// GoCheckModes are the modes for checking the Go code blocks of generated pages
+var GoCheckModes = []string{"off", "check", "repair"}
+
+// GoCheck is the result of checking a Go code block
+type GoCheck struct {
+	Source    string `json:"source"`
+	OK        bool   `json:"ok"`
+	Error     string `json:"error,omitempty"` // the first error, with the line number in the block
+	Typed     bool   `json:"typed"`           // if the types were checked, and not only the syntax
+	Formatted bool   `json:"formatted"`       // if the block is formatted like gofmt does it
+	Repaired  bool   `json:"repaired,omitempty"`
+}
+
+// goSnippet is a Go code block that has been parsed, possibly after being wrapped in a
+// package clause or in a function
+type goSnippet struct {
+	fset   *token.FileSet
+	file   *ast.File
+	offset int // the number of lines that were added before the block
+	decls  bool
+}
+
+// parseGoSnippet parses a Go code block that is either a whole file, a list of declarations or
+// a list of statements. If none of them parse, the error that is furthest into the block is returned.
+func parseGoSnippet(source string) (*goSnippet, error) {
+	var (
+		firstErr error
+		lastLine int
+	)
+	for _, wrap := range []struct {
+		prefix, suffix string
+		offset         int
+		decls          bool
+	}{
+		{"", "", 0, true},
+		{"package main\n", "", 1, true},
+		{"package main\nfunc _() {\n", "\n}", 2, false},
+	} {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "", wrap.prefix+source+wrap.suffix, parser.AllErrors)
+		if err == nil {
+			return &goSnippet{fset: fset, file: file, offset: wrap.offset, decls: wrap.decls}, nil
+		}
+		var list scanner.ErrorList
+		if !errors.As(err, &list) || len(list) == 0 {
+			return nil, err
+		}
+		if line := list[0].Pos.Line - wrap.offset; firstErr == nil || line > lastLine {
+			firstErr, lastLine = fmt.Errorf("line %d: %s", line, list[0].Msg), line
+		}
+	}
+	return nil, firstErr
+}
+
+// stdImporter type checks packages of the standard library from the Go source in GOROOT, and
+// refuses every other import path. Import paths are taken from generated code, so the go command
+// is never started for them, which importer.Default would do, and cgo is never run.
+type stdImporter struct {
+	mu       sync.Mutex
+	ctxt     build.Context
+	fset     *token.FileSet
+	packages map[string]*types.Package
+}
+
+// goStdImporter is shared by all checks, so that every package is only type checked once
+var goStdImporter = newStdImporter()
+
+// newStdImporter creates a new stdImporter for the installed Go
+func newStdImporter() *stdImporter {
+	ctxt := build.Default
+	ctxt.CgoEnabled = false
+	ctxt.GOPATH = ""
+	// With a file system callback, go/build finds packages by itself and never starts the go command
+	ctxt.IsDir = func(path string) bool {
+		info, err := os.Stat(path)
+		return err == nil && info.IsDir()
+	}
+	return &stdImporter{ctxt: ctxt, fset: token.NewFileSet(), packages: make(map[string]*types.Package)}
+}
+
+// Import imports the standard library package with the given path
+func (si *stdImporter) Import(path string) (*types.Package, error) {
+	if !isStdPackage(si.ctxt.GOROOT, path) {
+		return nil, fmt.Errorf("%q is not in the standard library", path)
+	}
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	return si.importFrom(path, "")
+}
+
+// importFrom type checks the package with the given path, as imported from the given directory,
+// which is needed for finding the packages that the standard library vendors
+func (si *stdImporter) importFrom(path, dir string) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+	bp, err := si.ctxt.Import(path, dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(bp.Dir, filepath.Join(si.ctxt.GOROOT, "src")+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%q is not in the standard library", path)
+	}
+	if pkg, ok := si.packages[bp.ImportPath]; ok {
+		return pkg, nil
+	}
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		file, err := parser.ParseFile(si.fset, filepath.Join(bp.Dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			return si.importFrom(path, bp.Dir)
+		}),
+		IgnoreFuncBodies: true,
+		FakeImportC:      true,
+		Error:            func(error) {}, // check as much as possible, like the compiler did
+	}
+	pkg, _ := conf.Check(bp.ImportPath, si.fset, files, nil)
+	si.packages[bp.ImportPath] = pkg
+	return pkg, nil
+}
+
+// importerFunc is a function that implements types.Importer
+type importerFunc func(path string) (*types.Package, error)
+
+// Import calls the function
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+// isStdPackage checks if the import path is a package in the standard library in the given GOROOT,
+// without starting the go command
+func isStdPackage(goroot, path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	if path == "" || first == "cmd" || strings.Contains(first, ".") || build.IsLocalImport(path) || strings.Contains(path, "\\") {
+		return false
+	}
+	for _, elem := range strings.Split(path, "/") {
+		if elem == "" || elem == "." || elem == ".." || elem == "internal" || elem == "vendor" || elem == "testdata" {
+			return false
+		}
+	}
+	if goroot == "" {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(goroot, "src", filepath.FromSlash(path)))
+	return err == nil && info.IsDir()
+}
+
+// recordingImporter is an importer that remembers if an import failed
+type recordingImporter struct {
+	importer types.Importer
+	failed   bool
+}
+
+// Import imports the package with the given path
+func (ri *recordingImporter) Import(path string) (*types.Package, error) {
+	pkg, err := ri.importer.Import(path)
+	if err != nil {
+		ri.failed = true
+	}
+	return pkg, err
+}
+
+// CheckGo checks that a Go code block parses, and that it type checks if it is self-contained.
+// A block is self-contained if it consists of declarations, only imports the standard library,
+// and it either has a package clause or refers to no undefined names.
+func CheckGo(source string) GoCheck {
+	check := GoCheck{Source: source}
+	if formatted, err := format.Source([]byte(source)); err == nil {
+		check.Formatted = strings.TrimSpace(string(formatted)) == strings.TrimSpace(source)
+	}
+	snippet, err := parseGoSnippet(source)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	check.OK = true
+	if !snippet.decls {
+		// Statements usually refer to declarations in other blocks
+		return check
+	}
+	imports := &recordingImporter{importer: goStdImporter}
+	undefined := false
+	conf := types.Config{Importer: imports, Error: func(err error) {
+		var typeErr types.Error
+		if errors.As(err, &typeErr) && strings.HasPrefix(typeErr.Msg, "undefined: ") {
+			undefined = true
+		}
+	}}
+	_, err = conf.Check(snippet.file.Name.Name, snippet.fset, []*ast.File{snippet.file}, nil)
+	if imports.failed || (undefined && snippet.offset > 0) {
+		// Declarations without a package clause often refer to imports and declarations in other blocks
+		return check
+	}
+	check.Typed = true
+	var typeErr types.Error
+	if errors.As(err, &typeErr) {
+		check.OK = false
+		check.Error = fmt.Sprintf("line %d: %s", typeErr.Fset.Position(typeErr.Pos).Line-snippet.offset, typeErr.Msg)
+	}
+	return check
+}
+
+// CheckGoBlocks checks the Go code blocks of a Markdown document, in the order they appear
+func CheckGoBlocks(markdown string) []GoCheck {
+	var checks []GoCheck
+	for _, block := range fencedBlocks(markdown) {
+		if block.lang == "go" || block.lang == "golang" {
+			checks = append(checks, CheckGo(block.source))
+		}
+	}
+	return checks
+}
+
+// goRepairPrompt asks the backend to fix a Go code block, and is followed by the error
+const goRepairPrompt = "The following Go code does not compile. The error is: "
+
+// RepairGo asks the generator once to fix a Go code block that did not pass CheckGo.
+// Returns the fixed code, or an error if the backend fails or if the fixed code does not pass.
+func RepairGo(ctx context.Context, g Generator, model string, check GoCheck) (GoCheck, error) {
+	prompt := goRepairPrompt + check.Error + "\n\nFix the code with as few changes as possible. Output only the fixed code in a single ```go block, with no commentary.\n\n```go\n" + check.Source + "\n```\n"
+	output, err := g.Generate(ctx, Request{Prompt: prompt, Model: model})
+	if err != nil {
+		return check, err
+	}
+	source := strings.TrimSpace(output)
+	for _, block := range fencedBlocks(output) {
+		if block.lang == "go" || block.lang == "golang" || block.lang == "" {
+			source = strings.Trim(block.source, "\n")
+			break
+		}
+	}
+	repaired := CheckGo(source)
+	if !repaired.OK {
+		return check, fmt.Errorf("the repaired code does not compile either: %s", repaired.Error)
+	}
+	repaired.Repaired = true
+	return repaired, nil
+}
+
+// checkGo checks the Go code blocks of the given Markdown, if enabled. In repair mode, the backend is
+// asked once to fix each failing block, and the fixed blocks replace the original ones in the Markdown.
+func (s *Server) checkGo(ctx context.Context, id PageID, markdown string) (string, []GoCheck) {
+	if s.GoCheck != "check" && s.GoCheck != "repair" {
+		return markdown, nil
+	}
+	checks := CheckGoBlocks(markdown)
+	if s.GoCheck != "repair" {
+		return markdown, checks
+	}
+	for i, check := range checks {
+		if check.OK {
+			continue
+		}
+		repaired, err := RepairGo(ctx, s.Generator, id.Model, check)
+		if err != nil {
+			log.Println("Error:", err)
+			continue
+		}
+		markdown = strings.Replace(markdown, "\n"+check.Source+"\n", "\n"+repaired.Source+"\n", 1)
+		checks[i] = repaired
+	}
+	return markdown, checks
+}
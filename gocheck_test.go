@@ -0,0 +1,36 @@
+package clickableai
+
+import (
+	"go/build"
+	"testing"
+)
+
+func TestCheckGoStdOnly(t *testing.T) {
+	check := CheckGo("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(1 + \"a\")\n}\n")
+	if !check.Typed || check.OK {
+		t.Errorf("a type error was not found: %+v", check)
+	}
+	check = CheckGo("package main\n\nimport \"example.com/tool\"\n\nfunc main() {\n\ttool.Run()\n}\n")
+	if check.Typed || !check.OK {
+		t.Errorf("a block with a package outside of the standard library was type checked: %+v", check)
+	}
+}
+
+func TestIsStdPackage(t *testing.T) {
+	for path, want := range map[string]bool{
+		"fmt":                true,
+		"net/http":           true,
+		"example.com/tool":   false,
+		"./local":            false,
+		"../fmt":             false,
+		"internal/bytealg":   false,
+		"cmd/go":             false,
+		"vendor/golang.org":  false,
+		"github.com/x/y/z/w": false,
+		"":                   false,
+	} {
+		if got := isStdPackage(build.Default.GOROOT, path); got != want {
+			t.Errorf("isStdPackage(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,494 @@
+package clickableai
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CodeBlock is a fenced code block from a Markdown document, with syntax highlighting
+type CodeBlock struct {
+	Lang   string        `json:"lang"`  // the language given after the fence
+	Label  string        `json:"label"` // the name of the language, for showing
+	Source string        `json:"source"`
+	HTML   template.HTML `json:"html"` // the source, with the tokens in <span class="hl-..."> tags
+}
+
+// fencedBlock is a fenced code block, with the language in lowercase
+type fencedBlock struct {
+	lang   string
+	source string
+}
+
+// fenceRegexp matches the start of a fenced code block, and the language after the fence
+var fenceRegexp = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([^\\s`]*)")
+
+// fencedBlocks returns the fenced code blocks of a Markdown document, in the order they appear
+func fencedBlocks(markdown string) []fencedBlock {
+	var (
+		blocks []fencedBlock
+		fence  string
+		lang   string
+		lines  []string
+	)
+	for _, line := range splitLines(markdown) {
+		if fence == "" {
+			if m := fenceRegexp.FindStringSubmatch(line); m != nil {
+				fence, lang, lines = m[1], strings.ToLower(m[2]), nil
+			}
+			continue
+		}
+		if isClosingFence(line, fence) {
+			blocks = append(blocks, fencedBlock{lang: lang, source: strings.Join(lines, "\n")})
+			fence = ""
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if fence != "" {
+		// An unterminated block lasts until the end of the document
+		blocks = append(blocks, fencedBlock{lang: lang, source: strings.Join(lines, "\n")})
+	}
+	return blocks
+}
+
+// isClosingFence checks if the line closes a code block that was opened with the given fence.
+// The closing fence is at least as long as the opening fence.
+func isClosingFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// HighlightCodeBlocks highlights the fenced code blocks of a Markdown document that are in
+// one of the supported languages, in the order they appear
+func HighlightCodeBlocks(markdown string) []CodeBlock {
+	var blocks []CodeBlock
+	for _, block := range fencedBlocks(markdown) {
+		l := lexerFor(block.lang)
+		if l == nil {
+			continue
+		}
+		blocks = append(blocks, CodeBlock{
+			Lang:   block.lang,
+			Label:  l.label,
+			Source: block.source,
+			HTML:   template.HTML(l.highlight(block.source)),
+		})
+	}
+	return blocks
+}
+
+// Highlight returns the source as HTML, with the tokens in <span> tags with the classes
+// hl-keyword, hl-type, hl-builtin, hl-function, hl-variable, hl-string, hl-number, hl-comment
+// and hl-meta. Returns false if the language is not supported.
+func Highlight(lang, source string) (template.HTML, bool) {
+	l := lexerFor(strings.ToLower(lang))
+	if l == nil {
+		return "", false
+	}
+	return template.HTML(l.highlight(source)), true
+}
+
+// lexer splits source code into tokens, and classifies them
+type lexer struct {
+	label             string
+	aliases           []string // the languages that can be given after the fence
+	keywords          map[string]bool
+	types             map[string]bool
+	builtins          map[string]bool
+	lineComments      []string
+	lineStartComments []string // comments that must be first on a line
+	blockComments     [][2]string
+	quotes            string // characters that start and end strings, with backslash escapes
+	rawQuotes         string // characters that start and end strings, without escapes
+	multilineQuotes   string // quotes that may span several lines
+	tripleQuotes      bool   // strings can be quoted with three quotes
+	lifetimes         bool   // a single quote that does not start a character literal is a lifetime
+	preprocessor      bool   // lines starting with # are directives
+	decorators        bool   // @name is a decorator
+	variables         bool   // $name is a variable
+	macros            bool   // name! is a macro
+	functions         bool   // a name followed by a parenthesis is a function
+	dotted            bool   // names with dots are instructions
+	instructions      bool   // the first name on a line is an instruction, and a name followed by a colon is a label
+	ignoreCase        bool
+	identStart        string // characters that can start a name, besides letters and _
+	identChars        string // characters that can be part of a name, besides letters, digits and _
+	registers         *regexp.Regexp
+}
+
+// words returns a set of the space-separated words
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(s) {
+		set[word] = true
+	}
+	return set
+}
+
+const (
+	cKeywords  = "auto break case const continue default do else enum extern for goto if inline register restrict return signed sizeof static struct switch typedef union unsigned volatile while _Alignas _Alignof _Atomic _Generic _Noreturn _Static_assert _Thread_local"
+	cTypes     = "bool char double float int long short void size_t ssize_t ptrdiff_t intptr_t uintptr_t int8_t int16_t int32_t int64_t uint8_t uint16_t uint32_t uint64_t FILE _Bool"
+	cBuiltins  = "NULL true false EOF stdin stdout stderr errno"
+	jsKeywords = "async await break case catch class const continue debugger default delete do else export extends finally for from function get if import in instanceof let new of return set static super switch this throw try typeof var void while with yield"
+	jsBuiltins = "true false null undefined NaN Infinity console window document globalThis Math JSON Promise Array Object String Number Boolean BigInt Symbol Map Set WeakMap Error RegExp Date"
+)
+
+// asmRegisterRegexp matches the registers of x86, x86-64 and ARM, also with the % prefix of AT&T syntax
+var asmRegisterRegexp = regexp.MustCompile(`(?i)^%?([re]?[abcd]x|[abcd][lh]|[re]?[sd]i|[sd]il|[re]?[sb]p|[sb]pl|[re]?ip|r(8|9|1[0-5])[dwb]?|[xyz]mm([0-9]|[12][0-9]|3[01])|[cdefgs]s|[xwrvqdsbh]([0-9]|[12][0-9]|30|31)|sp|lr|pc|fp|xzr|wzr)$`)
+
+// lexers are the supported languages
+var lexers = []*lexer{
+	{
+		label:         "Go",
+		aliases:       []string{"go", "golang"},
+		keywords:      words("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var"),
+		types:         words("any bool byte comparable complex64 complex128 error float32 float64 int int8 int16 int32 int64 rune string uint uint8 uint16 uint32 uint64 uintptr"),
+		builtins:      words("append cap clear close complex copy delete imag len make max min new panic print println real recover true false nil iota"),
+		lineComments:  []string{"//"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        `"'`,
+		rawQuotes:     "`",
+		functions:     true,
+	},
+	{
+		label:         "C",
+		aliases:       []string{"c", "h"},
+		keywords:      words(cKeywords),
+		types:         words(cTypes),
+		builtins:      words(cBuiltins),
+		lineComments:  []string{"//"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        `"'`,
+		preprocessor:  true,
+		functions:     true,
+	},
+	{
+		label:         "C++",
+		aliases:       []string{"cpp", "c++", "cc", "cxx", "hpp"},
+		keywords:      words(cKeywords + " catch class constexpr delete explicit friend mutable namespace new noexcept operator override private protected public template this throw try typename using virtual"),
+		types:         words(cTypes + " auto std string vector map"),
+		builtins:      words(cBuiltins + " nullptr cout cerr endl"),
+		lineComments:  []string{"//"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        `"'`,
+		preprocessor:  true,
+		functions:     true,
+	},
+	{
+		label:         "Rust",
+		aliases:       []string{"rust", "rs"},
+		keywords:      words("as async await break const continue crate dyn else enum extern fn for if impl in let loop match mod move mut pub ref return self Self static struct super trait type unsafe use where while"),
+		types:         words("bool char f32 f64 i8 i16 i32 i64 i128 isize u8 u16 u32 u64 u128 usize str String Vec Option Result Box Rc Arc HashMap"),
+		builtins:      words("true false Some None Ok Err"),
+		lineComments:  []string{"//"},
+		blockComments: [][2]string{{"/*", "*/"}},
+		quotes:        `"'`,
+		lifetimes:     true,
+		macros:        true,
+		functions:     true,
+	},
+	{
+		label:        "Python",
+		aliases:      []string{"python", "py", "python3"},
+		keywords:     words("and as assert async await break case class continue def del elif else except finally for from global if import in is lambda match nonlocal not or pass raise return try while with yield"),
+		types:        words("bool bytes dict float int list object set str tuple type"),
+		builtins:     words("True False None self cls print len range enumerate zip map filter open super isinstance sorted min max sum abs any all"),
+		lineComments: []string{"#"},
+		quotes:       `"'`,
+		tripleQuotes: true,
+		decorators:   true,
+		functions:    true,
+	},
+	{
+		label:           "JavaScript",
+		aliases:         []string{"javascript", "js", "jsx", "mjs", "node"},
+		keywords:        words(jsKeywords),
+		builtins:        words(jsBuiltins),
+		lineComments:    []string{"//"},
+		blockComments:   [][2]string{{"/*", "*/"}},
+		quotes:          "\"'`",
+		multilineQuotes: "`",
+		functions:       true,
+		identStart:      "$",
+		identChars:      "$",
+	},
+	{
+		label:           "TypeScript",
+		aliases:         []string{"typescript", "ts", "tsx"},
+		keywords:        words(jsKeywords + " abstract as declare enum implements interface keyof namespace private protected public readonly type"),
+		types:           words("any bigint boolean never number object string symbol unknown void"),
+		builtins:        words(jsBuiltins),
+		lineComments:    []string{"//"},
+		blockComments:   [][2]string{{"/*", "*/"}},
+		quotes:          "\"'`",
+		multilineQuotes: "`",
+		functions:       true,
+		identStart:      "$",
+		identChars:      "$",
+	},
+	{
+		label:             "Assembly",
+		aliases:           []string{"asm", "assembly", "nasm", "gas", "x86asm", "armasm", "s"},
+		lineComments:      []string{";", "//"},
+		lineStartComments: []string{"#"},
+		blockComments:     [][2]string{{"/*", "*/"}},
+		quotes:            `"'`,
+		instructions:      true,
+		ignoreCase:        true,
+		identStart:        ".%",
+		identChars:        ".",
+		registers:         asmRegisterRegexp,
+	},
+	{
+		label:         "WebAssembly",
+		aliases:       []string{"wat", "wast", "wasm", "webassembly"},
+		keywords:      words("module func param result local global export import memory table type call call_indirect br br_if br_table if then else end loop block return drop select mut data elem start unreachable nop offset"),
+		types:         words("i32 i64 f32 f64 v128 funcref externref"),
+		lineComments:  []string{";;"},
+		blockComments: [][2]string{{"(;", ";)"}},
+		quotes:        `"`,
+		variables:     true,
+		dotted:        true,
+		identChars:    ".",
+	},
+	{
+		label:        "Shell",
+		aliases:      []string{"sh", "bash", "shell", "zsh", "console"},
+		keywords:     words("if then else elif fi for while until do done case esac in function return local export select break continue"),
+		builtins:     words("echo cd ls cat grep sed awk printf read exit set unset source test true false sudo mkdir rm cp mv chmod curl wget git go cargo rustc gcc clang make npm node pip python python3"),
+		lineComments: []string{"#"},
+		quotes:       `"`,
+		rawQuotes:    "'",
+		variables:    true,
+	},
+	{
+		label:    "JSON",
+		aliases:  []string{"json"},
+		builtins: words("true false null"),
+		quotes:   `"`,
+	},
+}
+
+// lexerFor returns the lexer for the given language, or nil if it is not supported
+func lexerFor(lang string) *lexer {
+	for _, l := range lexers {
+		if contains(l.aliases, lang) {
+			return l
+		}
+	}
+	return nil
+}
+
+// highlight returns the source as HTML, with the tokens in <span> tags
+func (l *lexer) highlight(source string) string {
+	var sb strings.Builder
+	lineStart := true // if there is only whitespace since the start of the line
+	for i := 0; i < len(source); {
+		switch c := source[i]; c {
+		case '\n':
+			lineStart = true
+			fallthrough
+		case ' ', '\t', '\r':
+			sb.WriteByte(c)
+			i++
+			continue
+		}
+		class, n := l.token(source, i, lineStart)
+		text := source[i : i+n]
+		if class == "" {
+			sb.WriteString(html.EscapeString(text))
+		} else {
+			sb.WriteString(`<span class="hl-` + class + `">` + html.EscapeString(text) + `</span>`)
+		}
+		i += n
+		// In assembly, the instruction may come after a label
+		lineStart = l.instructions && lineStart && (class == "function" || text == ":")
+	}
+	return sb.String()
+}
+
+var (
+	// rustCharRegexp matches a Rust character literal
+	rustCharRegexp = regexp.MustCompile(`^'(\\[^']{1,10}|[^\\'])'`)
+	// shellVariableRegexp matches a shell variable
+	shellVariableRegexp = regexp.MustCompile(`^\$(\w+|\{[^}\n]*\}|[#?@*$!0-9-])`)
+	// watVariableRegexp matches a WebAssembly name
+	watVariableRegexp = regexp.MustCompile(`^\$[\w.!#$%&'*+/:<=>?@\\^|~-]+`)
+)
+
+// token returns the class and the length of the token that starts at position i of the source.
+// The class is empty for tokens that are not highlighted. The length is always at least 1.
+func (l *lexer) token(source string, i int, lineStart bool) (string, int) {
+	rest := source[i:]
+	c := rest[0]
+	for _, comment := range l.blockComments {
+		if strings.HasPrefix(rest, comment[0]) {
+			end := strings.Index(rest[len(comment[0]):], comment[1])
+			if end == -1 {
+				return "comment", len(rest)
+			}
+			return "comment", len(comment[0]) + end + len(comment[1])
+		}
+	}
+	for _, comment := range l.lineComments {
+		if strings.HasPrefix(rest, comment) {
+			return "comment", lineLength(rest)
+		}
+	}
+	for _, comment := range l.lineStartComments {
+		if lineStart && strings.HasPrefix(rest, comment) {
+			return "comment", lineLength(rest)
+		}
+	}
+	if l.preprocessor && lineStart && c == '#' {
+		return "meta", lineLength(rest)
+	}
+	if l.decorators && c == '@' {
+		if n := l.identLength(rest[1:]); n > 0 {
+			return "meta", 1 + n
+		}
+	}
+	if l.variables && c == '$' {
+		re := shellVariableRegexp
+		if l.dotted {
+			re = watVariableRegexp
+		}
+		if m := re.FindString(rest); m != "" {
+			return "variable", len(m)
+		}
+	}
+	if l.tripleQuotes && (strings.HasPrefix(rest, `"""`) || strings.HasPrefix(rest, "'''")) {
+		if end := strings.Index(rest[3:], rest[:3]); end != -1 {
+			return "string", end + 6
+		}
+		return "string", len(rest)
+	}
+	if strings.IndexByte(l.rawQuotes, c) != -1 {
+		if end := strings.IndexByte(rest[1:], c); end != -1 {
+			return "string", end + 2
+		}
+		return "string", len(rest)
+	}
+	if strings.IndexByte(l.quotes, c) != -1 {
+		if c == '\'' && l.lifetimes && !rustCharRegexp.MatchString(rest) {
+			return "type", 1 + l.identLength(rest[1:])
+		}
+		return "string", quotedLength(rest, strings.IndexByte(l.multilineQuotes, c) != -1)
+	}
+	if isDigit(c) || (c == '.' && len(rest) > 1 && isDigit(rest[1]) && (i == 0 || source[i-1] != '.')) {
+		return "number", numberLength(rest)
+	}
+	if n := l.identLength(rest); n > 0 {
+		return l.classify(rest[:n], rest[n:], lineStart), n
+	}
+	_, size := utf8.DecodeRuneInString(rest)
+	return "", size
+}
+
+// classify returns the class of a name, given the source that follows it
+func (l *lexer) classify(name, after string, lineStart bool) string {
+	key := name
+	if l.ignoreCase {
+		key = strings.ToLower(name)
+	}
+	next := strings.TrimLeft(after, " \t")
+	if l.instructions {
+		switch {
+		case strings.HasPrefix(next, ":"):
+			return "function" // a label
+		case strings.HasPrefix(name, "."):
+			return "meta" // a directive
+		case l.registers != nil && l.registers.MatchString(name):
+			return "builtin"
+		case lineStart:
+			return "keyword"
+		}
+		return ""
+	}
+	switch {
+	case l.keywords[key]:
+		return "keyword"
+	case l.types[key]:
+		return "type"
+	case l.builtins[key]:
+		return "builtin"
+	case l.macros && strings.HasPrefix(after, "!") && !strings.HasPrefix(after, "!="):
+		return "builtin"
+	case l.dotted && strings.Contains(name, "."):
+		return "builtin"
+	case l.functions && strings.HasPrefix(next, "("):
+		return "function"
+	}
+	return ""
+}
+
+// identLength returns the length of the name at the start of s, or 0 if s does not start with a name
+func (l *lexer) identLength(s string) int {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		valid := unicode.IsLetter(r) || r == '_' || strings.ContainsRune(l.identStart, r)
+		if n > 0 {
+			valid = valid || unicode.IsDigit(r) || strings.ContainsRune(l.identChars, r)
+		}
+		if !valid {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// quotedLength returns the length of the quoted string at the start of s, including the quotes.
+// Strings that are not closed end at the end of the line, unless they may span several lines.
+func quotedLength(s string, multiline bool) int {
+	quote := s[0]
+	for j := 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1
+		case '\n':
+			if !multiline {
+				return j
+			}
+		}
+	}
+	return len(s)
+}
+
+// numberLength returns the length of the number at the start of s, like 42, 0x1F, 1.5e-9 or 10u32
+func numberLength(s string) int {
+	hex := strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")
+	j := 0
+	for j < len(s) {
+		c := s[j]
+		switch {
+		case isDigit(c) || c == '_' || (c|0x20 >= 'a' && c|0x20 <= 'z'):
+		case c == '.' && j+1 < len(s) && isDigit(s[j+1]):
+		case (c == '+' || c == '-') && j > 0 && (s[j-1] == 'e' || s[j-1] == 'E') && !hex:
+		default:
+			return j
+		}
+		j++
+	}
+	return j
+}
+
+// lineLength returns the number of bytes until the end of the line
+func lineLength(s string) int {
+	if n := strings.IndexByte(s, '\n'); n != -1 {
+		return n
+	}
+	return len(s)
+}
+
+// isDigit checks if the byte is an ASCII digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,254 @@
+package clickableai
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// editTokenHeader is the header that trusted users can give the edit token in, instead of the "token" field
+const editTokenHeader = "X-Edit-Token"
+
+// maxEditSize is the maximum number of bytes in a request with edited Markdown
+const maxEditSize = 1024 * 1024
+
+// HistoryData is the version history of a page, with a diff between two of the versions
+type HistoryData struct {
+	Page    Page
+	Title   string
+	URL     string // opens the page and its trail
+	From    Version
+	To      Version
+	Diff    []DiffRow
+	Added   int
+	Removed int
+}
+
+// versionsResponse is the JSON response from /api/versions
+type versionsResponse struct {
+	Lang     string    `json:"lang"`
+	Model    string    `json:"model,omitempty"`
+	Trail    []string  `json:"trail"`
+	Version  int       `json:"version"`
+	Pinned   int       `json:"pinned,omitempty"`
+	Versions []Version `json:"versions"`
+}
+
+// canEdit checks if the request comes from a trusted user, who may edit pages and pin versions.
+// With authentication, trusted users are editors. Without it, they give the edit token.
+func (s *Server) canEdit(r *http.Request) bool {
+	if s.Auth != nil {
+		return UserFrom(r.Context()).Can(RoleEditor)
+	}
+	token := r.Header.Get(editTokenHeader)
+	if token == "" {
+		token = r.FormValue("token")
+	}
+	return s.EditToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.EditToken)) == 1
+}
+
+// editing checks if editing is possible for the user of the request, so that the editing
+// controls are shown. Without authentication, the edit token is asked for when it is needed.
+func (s *Server) editing(r *http.Request) bool {
+	if s.Auth != nil {
+		return UserFrom(r.Context()).Can(RoleEditor)
+	}
+	return s.EditToken != ""
+}
+
+// checkEdit responds with an error and returns false if the request is not a POST request from a trusted user
+func (s *Server) checkEdit(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Error: Use POST", http.StatusMethodNotAllowed)
+		return false
+	}
+	return s.checkTrusted(w, r)
+}
+
+// checkTrusted responds with an error and returns false if the request is not from a trusted user
+func (s *Server) checkTrusted(w http.ResponseWriter, r *http.Request) bool {
+	if s.Auth != nil {
+		return s.checkRole(w, r, RoleEditor)
+	}
+	if s.EditToken == "" {
+		http.Error(w, "Error: Editing is disabled", http.StatusForbidden)
+		return false
+	}
+	if !s.canEdit(r) {
+		http.Error(w, "Error: Invalid edit token", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// checkAdmin responds with an error and returns false if the request is not from an admin.
+// Without authentication, trusted users with the edit token are admins.
+func (s *Server) checkAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if s.Auth != nil {
+		return s.checkRole(w, r, RoleAdmin)
+	}
+	return s.checkTrusted(w, r)
+}
+
+// cachedPage responds with an error and returns false if the requested page has not been generated
+func (s *Server) cachedPage(w http.ResponseWriter, id PageID) (Page, bool) {
+	page, ok := s.Cache.Get(id)
+	if !ok || len(page.Versions) == 0 {
+		http.Error(w, "Error: The page has not been generated", http.StatusNotFound)
+		return Page{}, false
+	}
+	return page, true
+}
+
+// regenerateHandler generates a new version of a page, optionally with another "temperature"
+// or "backend". The new version is returned, and becomes canonical unless another version is pinned.
+func (s *Server) regenerateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Error: Use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	r.ParseForm()
+	if !s.checkRole(w, r, RoleEditor) {
+		return
+	}
+	id := s.pageID(r)
+	if !s.checkTrail(w, id.Trail) {
+		return
+	}
+	if _, ok := s.cachedPage(w, id); !ok {
+		return
+	}
+	temperature := s.MainTemperature
+	if value := r.FormValue("temperature"); value != "" {
+		t, err := strconv.ParseFloat(value, 64)
+		if err != nil || t < 0 || t > 2 {
+			http.Error(w, "Error: The temperature must be a number from 0 to 2", http.StatusBadRequest)
+			return
+		}
+		temperature = t
+	}
+
+	resp, sources, err := s.generateMarkdown(r.Context(), id, r.FormValue("backend"), "", temperature, nil)
+	if err != nil {
+		log.Println("Error:", err)
+		http.Error(w, "Error: Could not generate output", http.StatusBadGateway)
+		return
+	}
+	s.writePage(w, id, s.storePage(r.Context(), id, Version{
+		Kind:        VersionRegenerated,
+		Markdown:    resp.Text,
+		Backend:     resp.Backend,
+		Temperature: temperature,
+		Sources:     sources,
+		User:        userName(r),
+	}))
+}
+
+// editHandler stores the "markdown" field, edited by a trusted user, as a new version of a page
+func (s *Server) editHandler(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxEditSize)
+	if err := r.ParseForm(); err != nil {
+		status := http.StatusBadRequest
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, "Error: "+err.Error(), status)
+		return
+	}
+	if !s.checkEdit(w, r) {
+		return
+	}
+	id := s.pageID(r)
+	page, ok := s.cachedPage(w, id)
+	if !ok {
+		return
+	}
+	markdown := strings.ReplaceAll(r.FormValue("markdown"), "\r\n", "\n")
+	if strings.TrimSpace(markdown) == "" {
+		http.Error(w, "Error: The Markdown must not be empty", http.StatusBadRequest)
+		return
+	}
+	s.writePage(w, id, s.storePage(r.Context(), id, Version{
+		Kind:     VersionEdited,
+		Markdown: markdown,
+		Sources:  page.Sources,
+		User:     userName(r),
+	}))
+}
+
+// pinHandler makes the given "version" of a page canonical, or the latest version if it is 0
+func (s *Server) pinHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	if !s.checkEdit(w, r) {
+		return
+	}
+	number, err := strconv.Atoi(r.FormValue("version"))
+	if err != nil || number < 0 {
+		http.Error(w, "Error: Invalid version", http.StatusBadRequest)
+		return
+	}
+	id := s.pageID(r)
+	page, ok := s.Cache.Pin(id, number)
+	if !ok {
+		http.Error(w, "Error: No such page or version", http.StatusNotFound)
+		return
+	}
+	s.index(page)
+	s.writePage(w, id, page)
+}
+
+// versionsHandler returns all versions of a page as JSON
+func (s *Server) versionsHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	id := s.pageID(r)
+	page, ok := s.cachedPage(w, id)
+	if !ok {
+		return
+	}
+	writeJSON(w, versionsResponse{
+		Lang:     id.Lang,
+		Model:    id.Model,
+		Trail:    id.Trail,
+		Version:  page.Version,
+		Pinned:   page.Pinned,
+		Versions: page.Versions,
+	})
+}
+
+// historyHandler shows the versions of a page, and a side-by-side diff between the "from" and "to"
+// versions. By default, the latest version is compared with the version before it.
+func (s *Server) historyHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	id := s.pageID(r)
+	page, ok := s.cachedPage(w, id)
+	if !ok {
+		return
+	}
+	history := &HistoryData{
+		Page:  page,
+		Title: strings.Join(id.Trail, " → "),
+		URL:   Permalink(id),
+		To:    page.Versions[len(page.Versions)-1],
+	}
+	history.From = history.To
+	if len(page.Versions) > 1 {
+		history.From = page.Versions[len(page.Versions)-2]
+	}
+	for _, v := range []struct {
+		name    string
+		version *Version
+	}{{"from", &history.From}, {"to", &history.To}} {
+		if number, err := strconv.Atoi(r.FormValue(v.name)); err == nil {
+			if found, ok := page.FindVersion(number); ok {
+				*v.version = found
+			}
+		}
+	}
+	history.Diff = Diff(history.From.Markdown, history.To.Markdown)
+	history.Added, history.Removed = diffStats(history.Diff)
+	s.render(w, r, s.historyTmpl, PageData{History: history, Editing: s.editing(r), EditToken: s.Auth == nil})
+}
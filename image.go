@@ -0,0 +1,118 @@
+package clickableai
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// ImageTypes are the MIME types of the images that can be uploaded
+var ImageTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+// maxTitleLength is the maximum number of bytes in the title of an uploaded image
+const maxTitleLength = 80
+
+// uploadHandler receives an image in the "image" field of a multipart form, adds a short title
+// for it to the trail and generates a page that explains the image
+func (s *Server) uploadHandler(w http.ResponseWriter, r *http.Request) {
+	if s.MaxImageSize <= 0 {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Error: Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	img, err := readImage(w, r, s.MaxImageSize)
+	if err != nil {
+		log.Println("Error:", err)
+		status := http.StatusBadRequest
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, "Error: "+err.Error(), status)
+		return
+	}
+	id := s.pageID(r)
+	if !s.checkTrail(w, append(id.Trail, "")) || !s.checkModel(w, r) {
+		return
+	}
+
+	resp, err := GenerateResponse(r.Context(), s.Generator, Request{
+		Prompt: imageTitlePrompt + LanguageInstruction(id.Lang),
+		Model:  id.Model,
+		Images: []Image{img},
+	})
+	if err != nil {
+		log.Println("Error:", err)
+		http.Error(w, "Error: Could not describe the image", http.StatusBadGateway)
+		return
+	}
+	title := imageTitle(resp.Text)
+	if title == "" {
+		title = UIStringsFor(id.Lang).UploadImage
+	}
+	id.Trail = append(id.Trail, title)
+
+	resp, err = GenerateResponse(r.Context(), s.Generator, Request{
+		Prompt:      s.ImagePrompt + strings.Join(id.Trail, " -> ") + LanguageInstruction(id.Lang),
+		Temperature: s.MainTemperature,
+		Model:       id.Model,
+		Backend:     r.FormValue("backend"),
+		Images:      []Image{img},
+	})
+	if err != nil {
+		log.Println("Error:", err)
+		http.Error(w, "Error: Could not generate output", http.StatusBadGateway)
+		return
+	}
+	s.writePage(w, id, s.storePage(r.Context(), id, Version{
+		Kind:        VersionGenerated,
+		Markdown:    resp.Text,
+		Backend:     resp.Backend,
+		Temperature: s.MainTemperature,
+	}))
+}
+
+// readImage reads the "image" file of a multipart form, of up to maxSize bytes,
+// and checks that it is one of the ImageTypes
+func readImage(w http.ResponseWriter, r *http.Request, maxSize int) (Image, error) {
+	// Leave room for the other form fields
+	r.Body = http.MaxBytesReader(w, r.Body, int64(maxSize)+64*1024)
+	if err := r.ParseMultipartForm(int64(maxSize)); err != nil {
+		return Image{}, err
+	}
+	f, _, err := r.FormFile("image")
+	if err != nil {
+		return Image{}, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, int64(maxSize)+1))
+	if err != nil {
+		return Image{}, err
+	}
+	if len(data) > maxSize {
+		return Image{}, &http.MaxBytesError{Limit: int64(maxSize)}
+	}
+	mimeType := http.DetectContentType(data)
+	if !contains(ImageTypes, mimeType) {
+		return Image{}, errors.New("unsupported image type: " + mimeType)
+	}
+	return Image{MIMEType: mimeType, Data: data}, nil
+}
+
+// imageTitle cleans up the title of an image that was returned by a backend
+func imageTitle(output string) string {
+	title := strings.TrimSpace(output)
+	if pos := strings.IndexByte(title, '\n'); pos != -1 {
+		title = title[:pos]
+	}
+	title = strings.TrimLeft(title, "# ")
+	title = strings.Trim(title, " \t\"'`*.:")
+	// Commas would split the title into several keywords
+	title = strings.ReplaceAll(title, ",", "")
+	return strings.TrimSpace(truncate(title, maxTitleLength))
+}
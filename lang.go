@@ -0,0 +1,630 @@
+package clickableai
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage is the language used when no supported language can be negotiated
+const DefaultLanguage = "en"
+
+// UIStrings holds the localised strings that are used by index.html
+type UIStrings struct {
+	Title             string
+	AvailableKeywords string
+	CurrentKeywords   string
+	AddSelectedText   string
+	GeneratedContent  string
+	Offline           string
+	GenerateError     string
+	Model             string
+	DefaultModel      string
+	PullModel         string
+	ModelInfo         string
+	Prerequisites     string
+	Deeper            string
+	Related           string
+	Alternatives      string
+	Search            string
+	NoResults         string
+	Sources           string
+	Review            string
+	Uncertain         string
+	UploadImage       string
+	DropImage         string
+	Copy              string
+	Copied            string
+	Compiles          string
+	DoesNotCompile    string
+	Repaired          string
+	Contents          string
+	GoDeeper          string
+	Regenerate        string
+	Temperature       string
+	Edit              string
+	Save              string
+	Cancel            string
+	History           string
+	Version           string
+	Pin               string
+	Unpin             string
+	Pinned            string
+	Compare           string
+	EditToken         string
+	OpenPage          string
+	Comment           string
+	Helpful           string
+	NotHelpful        string
+	ThanksForFeedback string
+	FeedbackReport    string
+	Score             string
+	NoFeedback        string
+	CopyLink          string
+	Bookmark          string
+	Bookmarked        string
+	Bookmarks         string
+	NewCollection     string
+	Rename            string
+	Delete            string
+	Export            string
+	Remove            string
+	MoveUp            string
+	MoveDown          string
+	MoveTo            string
+	NoBookmarks       string
+	Dashboard         string
+	Uptime            string
+	Requests          string
+	RequestRate       string
+	Queue             string
+	CachedPages       string
+	CacheHitRatio     string
+	TopTopics         string
+	TopTrails         string
+	Backends          string
+	Latency           string
+	ErrorRate         string
+	Tokens            string
+	RecentErrors      string
+	NoErrors          string
+	Purge             string
+	PurgeBelow        string
+	PurgeAll          string
+	MakeDefault       string
+}
+
+// languageNames maps supported language codes to the name used when instructing the backend
+var languageNames = map[string]string{
+	"de": "German",
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"nb": "Norwegian Bokmål",
+}
+
+// uiStrings maps supported language codes to localised UI strings
+var uiStrings = map[string]UIStrings{
+	"de": {
+		Title:             "Plink Scrunk",
+		AvailableKeywords: "Verfügbare Stichwörter",
+		CurrentKeywords:   "Aktuelle Stichwörter",
+		AddSelectedText:   "Markierten Text hinzufügen",
+		GeneratedContent:  "Generierter Inhalt",
+		Offline:           "Sie sind offline. Bitte überprüfen Sie Ihre Internetverbindung.",
+		GenerateError:     "Beim Generieren ist ein Fehler aufgetreten. Bitte versuchen Sie es später erneut.",
+		Model:             "Modell",
+		DefaultModel:      "Standardmodell",
+		PullModel:         "Modell herunterladen",
+		ModelInfo:         "Modellinfo",
+		Prerequisites:     "Voraussetzungen",
+		Deeper:            "Vertiefung",
+		Related:           "Verwandt",
+		Alternatives:      "Alternativen",
+		Search:            "Suchen",
+		NoResults:         "Keine Seiten gefunden.",
+		Sources:           "Quellen",
+		Review:            "Überprüfung",
+		Uncertain:         "Unsicher",
+		UploadImage:       "Bild hochladen",
+		DropImage:         "Bild hier ablegen, um es zu erklären",
+		Copy:              "Kopieren",
+		Copied:            "Kopiert",
+		Compiles:          "Kompiliert",
+		DoesNotCompile:    "Kompiliert nicht",
+		Repaired:          "Repariert",
+		Contents:          "Inhalt",
+		GoDeeper:          "Diesen Abschnitt vertiefen",
+		Regenerate:        "Neu generieren",
+		Temperature:       "Temperatur",
+		Edit:              "Bearbeiten",
+		Save:              "Speichern",
+		Cancel:            "Abbrechen",
+		History:           "Verlauf",
+		Version:           "Version",
+		Pin:               "Anheften",
+		Unpin:             "Lösen",
+		Pinned:            "Angeheftet",
+		Compare:           "Vergleichen",
+		EditToken:         "Bearbeitungstoken",
+		OpenPage:          "Seite öffnen",
+		Comment:           "Kommentar (optional)",
+		Helpful:           "Hilfreich",
+		NotHelpful:        "Nicht hilfreich",
+		ThanksForFeedback: "Danke für Ihr Feedback!",
+		FeedbackReport:    "Am schlechtesten bewertete Seiten",
+		Score:             "Bewertung",
+		NoFeedback:        "Noch kein Feedback.",
+		CopyLink:          "Link kopieren",
+		Bookmark:          "Lesezeichen setzen",
+		Bookmarked:        "Gespeichert",
+		Bookmarks:         "Lesezeichen",
+		NewCollection:     "Neue Sammlung",
+		Rename:            "Umbenennen",
+		Delete:            "Löschen",
+		Export:            "Exportieren",
+		Remove:            "Entfernen",
+		MoveUp:            "Nach oben",
+		MoveDown:          "Nach unten",
+		MoveTo:            "Verschieben nach",
+		NoBookmarks:       "Noch keine Lesezeichen.",
+		Dashboard:         "Admin-Übersicht",
+		Uptime:            "Laufzeit",
+		Requests:          "Anfragen",
+		RequestRate:       "Anfragen pro Sekunde",
+		Queue:             "In Arbeit / wartend",
+		CachedPages:       "Seiten im Cache",
+		CacheHitRatio:     "Cache-Trefferquote",
+		TopTopics:         "Häufigste Themen",
+		TopTrails:         "Häufigste Pfade",
+		Backends:          "Backends",
+		Latency:           "Latenz",
+		ErrorRate:         "Fehlerquote",
+		Tokens:            "Geschätzte Tokens (Prompt / Ausgabe)",
+		RecentErrors:      "Letzte Fehler",
+		NoErrors:          "Keine Fehler.",
+		Purge:             "Entfernen",
+		PurgeBelow:        "Mit den Seiten darunter entfernen",
+		PurgeAll:          "Alle Seiten entfernen",
+		MakeDefault:       "Als Standard",
+	},
+	"en": {
+		Title:             "Plink Scrunk",
+		AvailableKeywords: "Available keywords",
+		CurrentKeywords:   "Current keywords",
+		AddSelectedText:   "Add selected text",
+		GeneratedContent:  "Generated Content",
+		Offline:           "You are offline. Please check your internet connection.",
+		GenerateError:     "An error occurred while generating content. Please try again later.",
+		Model:             "Model",
+		DefaultModel:      "Default model",
+		PullModel:         "Pull model",
+		ModelInfo:         "Model info",
+		Prerequisites:     "Prerequisites",
+		Deeper:            "Go deeper",
+		Related:           "Related",
+		Alternatives:      "Alternatives",
+		Search:            "Search",
+		NoResults:         "No pages found.",
+		Sources:           "Sources",
+		Review:            "Review",
+		Uncertain:         "Uncertain",
+		UploadImage:       "Upload image",
+		DropImage:         "Drop an image here to explain it",
+		Copy:              "Copy",
+		Copied:            "Copied",
+		Compiles:          "Compiles",
+		DoesNotCompile:    "Does not compile",
+		Repaired:          "Repaired",
+		Contents:          "Contents",
+		GoDeeper:          "Go deeper into this section",
+		Regenerate:        "Regenerate",
+		Temperature:       "Temperature",
+		Edit:              "Edit",
+		Save:              "Save",
+		Cancel:            "Cancel",
+		History:           "History",
+		Version:           "Version",
+		Pin:               "Pin",
+		Unpin:             "Unpin",
+		Pinned:            "Pinned",
+		Compare:           "Compare",
+		EditToken:         "Edit token",
+		OpenPage:          "Open page",
+		Comment:           "Comment (optional)",
+		Helpful:           "Helpful",
+		NotHelpful:        "Not helpful",
+		ThanksForFeedback: "Thanks for the feedback!",
+		FeedbackReport:    "Worst-rated pages",
+		Score:             "Score",
+		NoFeedback:        "No feedback yet.",
+		CopyLink:          "Copy link",
+		Bookmark:          "Bookmark",
+		Bookmarked:        "Bookmarked",
+		Bookmarks:         "Bookmarks",
+		NewCollection:     "New collection",
+		Rename:            "Rename",
+		Delete:            "Delete",
+		Export:            "Export",
+		Remove:            "Remove",
+		MoveUp:            "Move up",
+		MoveDown:          "Move down",
+		MoveTo:            "Move to",
+		NoBookmarks:       "No bookmarks yet.",
+		Dashboard:         "Admin dashboard",
+		Uptime:            "Uptime",
+		Requests:          "Requests",
+		RequestRate:       "Requests per second",
+		Queue:             "Generating / waiting",
+		CachedPages:       "Cached pages",
+		CacheHitRatio:     "Cache hit ratio",
+		TopTopics:         "Top topics",
+		TopTrails:         "Top trails",
+		Backends:          "Backends",
+		Latency:           "Latency",
+		ErrorRate:         "Error rate",
+		Tokens:            "Estimated tokens (prompt / output)",
+		RecentErrors:      "Recent errors",
+		NoErrors:          "No errors.",
+		Purge:             "Purge",
+		PurgeBelow:        "Purge with the pages below",
+		PurgeAll:          "Purge all pages",
+		MakeDefault:       "Make default",
+	},
+	"es": {
+		Title:             "Plink Scrunk",
+		AvailableKeywords: "Palabras clave disponibles",
+		CurrentKeywords:   "Palabras clave actuales",
+		AddSelectedText:   "Añadir texto seleccionado",
+		GeneratedContent:  "Contenido generado",
+		Offline:           "Estás sin conexión. Comprueba tu conexión a Internet.",
+		GenerateError:     "Se produjo un error al generar el contenido. Inténtalo de nuevo más tarde.",
+		Model:             "Modelo",
+		DefaultModel:      "Modelo predeterminado",
+		PullModel:         "Descargar modelo",
+		ModelInfo:         "Información del modelo",
+		Prerequisites:     "Requisitos previos",
+		Deeper:            "Profundizar",
+		Related:           "Relacionado",
+		Alternatives:      "Alternativas",
+		Search:            "Buscar",
+		NoResults:         "No se encontraron páginas.",
+		Sources:           "Fuentes",
+		Review:            "Revisión",
+		Uncertain:         "Incierto",
+		UploadImage:       "Subir imagen",
+		DropImage:         "Suelta una imagen aquí para explicarla",
+		Copy:              "Copiar",
+		Copied:            "Copiado",
+		Compiles:          "Compila",
+		DoesNotCompile:    "No compila",
+		Repaired:          "Reparado",
+		Contents:          "Contenido",
+		GoDeeper:          "Profundizar en esta sección",
+		Regenerate:        "Regenerar",
+		Temperature:       "Temperatura",
+		Edit:              "Editar",
+		Save:              "Guardar",
+		Cancel:            "Cancelar",
+		History:           "Historial",
+		Version:           "Versión",
+		Pin:               "Fijar",
+		Unpin:             "Desfijar",
+		Pinned:            "Fijada",
+		Compare:           "Comparar",
+		EditToken:         "Token de edición",
+		OpenPage:          "Abrir página",
+		Comment:           "Comentario (opcional)",
+		Helpful:           "Útil",
+		NotHelpful:        "No es útil",
+		ThanksForFeedback: "¡Gracias por tu opinión!",
+		FeedbackReport:    "Páginas peor valoradas",
+		Score:             "Puntuación",
+		NoFeedback:        "Todavía no hay opiniones.",
+		CopyLink:          "Copiar enlace",
+		Bookmark:          "Guardar",
+		Bookmarked:        "Guardado",
+		Bookmarks:         "Marcadores",
+		NewCollection:     "Nueva colección",
+		Rename:            "Renombrar",
+		Delete:            "Eliminar",
+		Export:            "Exportar",
+		Remove:            "Quitar",
+		MoveUp:            "Subir",
+		MoveDown:          "Bajar",
+		MoveTo:            "Mover a",
+		NoBookmarks:       "Todavía no hay marcadores.",
+		Dashboard:         "Panel de administración",
+		Uptime:            "Tiempo activo",
+		Requests:          "Solicitudes",
+		RequestRate:       "Solicitudes por segundo",
+		Queue:             "Generando / en espera",
+		CachedPages:       "Páginas en caché",
+		CacheHitRatio:     "Tasa de aciertos de caché",
+		TopTopics:         "Temas principales",
+		TopTrails:         "Rutas principales",
+		Backends:          "Backends",
+		Latency:           "Latencia",
+		ErrorRate:         "Tasa de errores",
+		Tokens:            "Tokens estimados (prompt / salida)",
+		RecentErrors:      "Errores recientes",
+		NoErrors:          "No hay errores.",
+		Purge:             "Purgar",
+		PurgeBelow:        "Purgar con las páginas de debajo",
+		PurgeAll:          "Purgar todas las páginas",
+		MakeDefault:       "Hacer predeterminado",
+	},
+	"fr": {
+		Title:             "Plink Scrunk",
+		AvailableKeywords: "Mots-clés disponibles",
+		CurrentKeywords:   "Mots-clés actuels",
+		AddSelectedText:   "Ajouter le texte sélectionné",
+		GeneratedContent:  "Contenu généré",
+		Offline:           "Vous êtes hors ligne. Veuillez vérifier votre connexion Internet.",
+		GenerateError:     "Une erreur s'est produite lors de la génération du contenu. Veuillez réessayer plus tard.",
+		Model:             "Modèle",
+		DefaultModel:      "Modèle par défaut",
+		PullModel:         "Télécharger le modèle",
+		ModelInfo:         "Infos sur le modèle",
+		Prerequisites:     "Prérequis",
+		Deeper:            "Approfondir",
+		Related:           "Connexe",
+		Alternatives:      "Alternatives",
+		Search:            "Rechercher",
+		NoResults:         "Aucune page trouvée.",
+		Sources:           "Sources",
+		Review:            "Relecture",
+		Uncertain:         "Incertain",
+		UploadImage:       "Téléverser une image",
+		DropImage:         "Déposez une image ici pour l'expliquer",
+		Copy:              "Copier",
+		Copied:            "Copié",
+		Compiles:          "Compile",
+		DoesNotCompile:    "Ne compile pas",
+		Repaired:          "Réparé",
+		Contents:          "Sommaire",
+		GoDeeper:          "Approfondir cette section",
+		Regenerate:        "Régénérer",
+		Temperature:       "Température",
+		Edit:              "Modifier",
+		Save:              "Enregistrer",
+		Cancel:            "Annuler",
+		History:           "Historique",
+		Version:           "Version",
+		Pin:               "Épingler",
+		Unpin:             "Désépingler",
+		Pinned:            "Épinglée",
+		Compare:           "Comparer",
+		EditToken:         "Jeton de modification",
+		OpenPage:          "Ouvrir la page",
+		Comment:           "Commentaire (facultatif)",
+		Helpful:           "Utile",
+		NotHelpful:        "Pas utile",
+		ThanksForFeedback: "Merci pour votre avis !",
+		FeedbackReport:    "Pages les moins bien notées",
+		Score:             "Score",
+		NoFeedback:        "Aucun avis pour le moment.",
+		CopyLink:          "Copier le lien",
+		Bookmark:          "Ajouter aux favoris",
+		Bookmarked:        "Ajouté",
+		Bookmarks:         "Favoris",
+		NewCollection:     "Nouvelle collection",
+		Rename:            "Renommer",
+		Delete:            "Supprimer",
+		Export:            "Exporter",
+		Remove:            "Retirer",
+		MoveUp:            "Monter",
+		MoveDown:          "Descendre",
+		MoveTo:            "Déplacer vers",
+		NoBookmarks:       "Aucun favori pour le moment.",
+		Dashboard:         "Tableau de bord d'administration",
+		Uptime:            "Disponibilité",
+		Requests:          "Requêtes",
+		RequestRate:       "Requêtes par seconde",
+		Queue:             "En cours / en attente",
+		CachedPages:       "Pages en cache",
+		CacheHitRatio:     "Taux de succès du cache",
+		TopTopics:         "Sujets principaux",
+		TopTrails:         "Parcours principaux",
+		Backends:          "Backends",
+		Latency:           "Latence",
+		ErrorRate:         "Taux d'erreur",
+		Tokens:            "Tokens estimés (prompt / sortie)",
+		RecentErrors:      "Erreurs récentes",
+		NoErrors:          "Aucune erreur.",
+		Purge:             "Purger",
+		PurgeBelow:        "Purger avec les pages en dessous",
+		PurgeAll:          "Purger toutes les pages",
+		MakeDefault:       "Par défaut",
+	},
+	"nb": {
+		Title:             "Plink Scrunk",
+		AvailableKeywords: "Tilgjengelige nøkkelord",
+		CurrentKeywords:   "Valgte nøkkelord",
+		AddSelectedText:   "Legg til markert tekst",
+		GeneratedContent:  "Generert innhold",
+		Offline:           "Du er frakoblet. Sjekk internettforbindelsen din.",
+		GenerateError:     "Det oppstod en feil under genereringen. Prøv igjen senere.",
+		Model:             "Modell",
+		DefaultModel:      "Standardmodell",
+		PullModel:         "Last ned modell",
+		ModelInfo:         "Modellinfo",
+		Prerequisites:     "Forkunnskaper",
+		Deeper:            "Gå dypere",
+		Related:           "Relatert",
+		Alternatives:      "Alternativer",
+		Search:            "Søk",
+		NoResults:         "Fant ingen sider.",
+		Sources:           "Kilder",
+		Review:            "Gjennomgang",
+		Uncertain:         "Usikkert",
+		UploadImage:       "Last opp bilde",
+		DropImage:         "Slipp et bilde her for å få det forklart",
+		Copy:              "Kopier",
+		Copied:            "Kopiert",
+		Compiles:          "Kompilerer",
+		DoesNotCompile:    "Kompilerer ikke",
+		Repaired:          "Reparert",
+		Contents:          "Innhold",
+		GoDeeper:          "Gå dypere inn i denne delen",
+		Regenerate:        "Generer på nytt",
+		Temperature:       "Temperatur",
+		Edit:              "Rediger",
+		Save:              "Lagre",
+		Cancel:            "Avbryt",
+		History:           "Historikk",
+		Version:           "Versjon",
+		Pin:               "Fest",
+		Unpin:             "Løsne",
+		Pinned:            "Festet",
+		Compare:           "Sammenlign",
+		EditToken:         "Redigeringsnøkkel",
+		OpenPage:          "Åpne siden",
+		Comment:           "Kommentar (valgfri)",
+		Helpful:           "Nyttig",
+		NotHelpful:        "Ikke nyttig",
+		ThanksForFeedback: "Takk for tilbakemeldingen!",
+		FeedbackReport:    "Dårligst vurderte sider",
+		Score:             "Poengsum",
+		NoFeedback:        "Ingen tilbakemeldinger ennå.",
+		CopyLink:          "Kopier lenke",
+		Bookmark:          "Bokmerk",
+		Bookmarked:        "Bokmerket",
+		Bookmarks:         "Bokmerker",
+		NewCollection:     "Ny samling",
+		Rename:            "Gi nytt navn",
+		Delete:            "Slett",
+		Export:            "Eksporter",
+		Remove:            "Fjern",
+		MoveUp:            "Flytt opp",
+		MoveDown:          "Flytt ned",
+		MoveTo:            "Flytt til",
+		NoBookmarks:       "Ingen bokmerker ennå.",
+		Dashboard:         "Adminoversikt",
+		Uptime:            "Oppetid",
+		Requests:          "Forespørsler",
+		RequestRate:       "Forespørsler per sekund",
+		Queue:             "Genereres / venter",
+		CachedPages:       "Sider i hurtigbufferen",
+		CacheHitRatio:     "Treffrate for hurtigbufferen",
+		TopTopics:         "Mest brukte emner",
+		TopTrails:         "Mest brukte stier",
+		Backends:          "Backender",
+		Latency:           "Forsinkelse",
+		ErrorRate:         "Feilrate",
+		Tokens:            "Anslåtte tokens (prompt / utdata)",
+		RecentErrors:      "Siste feil",
+		NoErrors:          "Ingen feil.",
+		Purge:             "Fjern",
+		PurgeBelow:        "Fjern med sidene under",
+		PurgeAll:          "Fjern alle sider",
+		MakeDefault:       "Gjør til standard",
+	},
+}
+
+// languageAliases maps language codes that should be treated as one of the supported languages
+var languageAliases = map[string]string{
+	"no": "nb",
+	"nn": "nb",
+}
+
+// SupportedLanguages returns a sorted list of the supported language codes
+func SupportedLanguages() []string {
+	langs := make([]string, 0, len(languageNames))
+	for lang := range languageNames {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// LanguageName returns the English name of the given language code, or the code itself if it is unknown
+func LanguageName(lang string) string {
+	if name, ok := languageNames[lang]; ok {
+		return name
+	}
+	return lang
+}
+
+// UIStringsFor returns the localised UI strings for the given language, falling back to English
+func UIStringsFor(lang string) UIStrings {
+	if s, ok := uiStrings[lang]; ok {
+		return s
+	}
+	return uiStrings[DefaultLanguage]
+}
+
+// LanguageInstruction returns a sentence that can be appended to a prompt to select the output language
+func LanguageInstruction(lang string) string {
+	if lang = NormalizeLanguage(lang); lang == "" {
+		lang = DefaultLanguage
+	}
+	return "\n\nWrite the output in " + LanguageName(lang) + "."
+}
+
+// NormalizeLanguage turns a language tag like "en-US" or "NO" into a supported language code.
+// Returns an empty string if the language is not supported.
+func NormalizeLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if pos := strings.IndexAny(tag, "-_"); pos != -1 {
+		tag = tag[:pos]
+	}
+	if alias, ok := languageAliases[tag]; ok {
+		tag = alias
+	}
+	if _, ok := languageNames[tag]; ok {
+		return tag
+	}
+	return ""
+}
+
+// NegotiateLanguage picks an output language for the given request.
+// An explicit "lang" parameter takes precedence over the Accept-Language header.
+func NegotiateLanguage(r *http.Request) string {
+	if lang := NormalizeLanguage(r.FormValue("lang")); lang != "" {
+		return lang
+	}
+	for _, tag := range parseAcceptLanguage(r.Header.Get("Accept-Language")) {
+		if lang := NormalizeLanguage(tag); lang != "" {
+			return lang
+		}
+	}
+	return DefaultLanguage
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header, ordered by quality
+func parseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag string
+		q   float64
+	}
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if f, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weightedTag{tag, q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+	result := make([]string, len(tags))
+	for i, wt := range tags {
+		result[i] = wt.tag
+	}
+	return result
+}
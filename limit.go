@@ -0,0 +1,82 @@
+package clickableai
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Limiter is a Generator that wraps another Generator and limits the number of concurrent requests to it
+type Limiter struct {
+	Generator Generator
+
+	slots   chan struct{}
+	waiting atomic.Int64
+}
+
+// NewLimiter creates a new Limiter that allows up to n concurrent requests to g
+func NewLimiter(g Generator, n int) *Limiter {
+	return &Limiter{Generator: g, slots: make(chan struct{}, n)}
+}
+
+// Name returns the name of the wrapped backend
+func (l *Limiter) Name() string {
+	return l.Generator.Name()
+}
+
+// Generate waits for a free slot and then calls the wrapped Generator
+func (l *Limiter) Generate(ctx context.Context, req Request) (string, error) {
+	resp, err := l.GenerateResponse(ctx, req)
+	return resp.Text, err
+}
+
+// GenerateResponse waits for a free slot and then calls the wrapped Generator,
+// reporting which backend produced the output
+func (l *Limiter) GenerateResponse(ctx context.Context, req Request) (Response, error) {
+	if err := l.acquire(ctx); err != nil {
+		return Response{}, err
+	}
+	defer l.release()
+	return GenerateResponse(ctx, l.Generator, req)
+}
+
+// GenerateResponseStream waits for a free slot and then streams from the wrapped Generator,
+// reporting which backend produced the output
+func (l *Limiter) GenerateResponseStream(ctx context.Context, req Request, callback func(chunk string)) (Response, error) {
+	if err := l.acquire(ctx); err != nil {
+		return Response{}, err
+	}
+	defer l.release()
+	return GenerateResponseStream(ctx, l.Generator, req, callback)
+}
+
+// InFlight returns the number of requests that are currently being generated
+func (l *Limiter) InFlight() int {
+	return len(l.slots)
+}
+
+// Waiting returns the number of requests that are waiting for a free slot
+func (l *Limiter) Waiting() int {
+	return int(l.waiting.Load())
+}
+
+// Max returns the maximum number of concurrent requests
+func (l *Limiter) Max() int {
+	return cap(l.slots)
+}
+
+// acquire waits for a free slot, or until the context is done
+func (l *Limiter) acquire(ctx context.Context) error {
+	l.waiting.Add(1)
+	defer l.waiting.Add(-1)
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot
+func (l *Limiter) release() {
+	<-l.slots
+}
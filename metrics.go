@@ -0,0 +1,333 @@
+package clickableai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// rateWindow is the number of seconds that the request rate is measured over
+	rateWindow = 60
+	// maxTracked is the maximum number of different topics and trails that are counted
+	maxTracked = 10000
+	// maxRecentErrors is the number of recent errors that are remembered
+	maxRecentErrors = 50
+	// bytesPerToken is roughly how many bytes of text there are per token, for estimating token spend
+	bytesPerToken = 4
+)
+
+// BackendUsage is how much a backend has been used, with the number of tokens estimated from the
+// length of the prompts and the generated text
+type BackendUsage struct {
+	Name           string        `json:"name"`
+	Requests       int64         `json:"requests"`
+	Errors         int64         `json:"errors"`
+	PromptTokens   int64         `json:"prompt_tokens"`
+	OutputTokens   int64         `json:"output_tokens"`
+	AverageLatency time.Duration `json:"average_latency"`
+
+	totalLatency time.Duration
+}
+
+// ErrorPercent returns the percentage of the requests to the backend that failed
+func (bu BackendUsage) ErrorPercent() float64 {
+	if bu.Requests == 0 {
+		return 0
+	}
+	return 100 * float64(bu.Errors) / float64(bu.Requests)
+}
+
+// Count is a topic or a trail, together with how many times it was opened
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// RecentError is an error that was logged
+type RecentError struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// MetricsSnapshot is a snapshot of the metrics, for the admin dashboard
+type MetricsSnapshot struct {
+	Uptime        time.Duration  `json:"uptime"`
+	Requests      int64          `json:"requests"`
+	ServerErrors  int64          `json:"server_errors"` // responses with a 5xx status code
+	RequestRate   float64        `json:"request_rate"`  // requests per second, over the last minute
+	Generating    int64          `json:"generating"`    // backend requests that are being generated
+	CacheHits     int64          `json:"cache_hits"`
+	CacheMisses   int64          `json:"cache_misses"`
+	CacheHitRatio float64        `json:"cache_hit_ratio"`
+	TopTopics     []Count        `json:"top_topics"`
+	TopTrails     []Count        `json:"top_trails"`
+	Backends      []BackendUsage `json:"backends"`
+	Errors        []RecentError  `json:"errors"` // newest first
+}
+
+// CacheHitPercent returns the percentage of the opened pages that were in the cache
+func (ms MetricsSnapshot) CacheHitPercent() float64 {
+	return 100 * ms.CacheHitRatio
+}
+
+// Metrics is a concurrency-safe record of the requests, page views and backend usage of a server.
+// It is also an io.Writer, that remembers the logged lines with errors.
+type Metrics struct {
+	mu           sync.Mutex
+	started      time.Time
+	seconds      [rateWindow]int64 // the number of requests in each of the last seconds
+	lastSecond   int64             // the Unix time of the newest count in seconds
+	requests     int64
+	serverErrors int64
+	cacheHits    int64
+	cacheMisses  int64
+	topics       map[string]int
+	trails       map[string]int
+	backends     map[string]*BackendUsage
+	errors       []RecentError
+	generating   atomic.Int64
+}
+
+// NewMetrics creates a new and empty Metrics
+func NewMetrics() *Metrics {
+	return &Metrics{
+		started:  time.Now(),
+		topics:   make(map[string]int),
+		trails:   make(map[string]int),
+		backends: make(map[string]*BackendUsage),
+	}
+}
+
+// RecordRequest counts a request to the server, with the status code of the response
+func (m *Metrics) RecordRequest(status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advance(time.Now().Unix())
+	m.seconds[m.lastSecond%rateWindow]++
+	m.requests++
+	if status >= 500 {
+		m.serverErrors++
+	}
+}
+
+// advance moves the request rate window forward to the given second, clearing the
+// seconds in between. The caller must hold the lock.
+func (m *Metrics) advance(now int64) {
+	if now <= m.lastSecond {
+		return
+	}
+	for second := max(m.lastSecond+1, now-rateWindow+1); second <= now; second++ {
+		m.seconds[second%rateWindow] = 0
+	}
+	m.lastSecond = now
+}
+
+// RecordPage counts a page that was opened, and if it was in the cache
+func (m *Metrics) RecordPage(id PageID, cached bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cached {
+		m.cacheHits++
+	} else {
+		m.cacheMisses++
+	}
+	if len(id.Trail) == 0 {
+		return
+	}
+	increment(m.topics, id.Trail[len(id.Trail)-1])
+	increment(m.trails, strings.Join(id.Trail, " → "))
+}
+
+// increment counts a name, unless there are too many names already
+func increment(counts map[string]int, name string) {
+	if _, ok := counts[name]; ok || len(counts) < maxTracked {
+		counts[name]++
+	}
+}
+
+// RecordBackend records a request to a backend, with how long it took, the lengths of the prompt
+// and the generated text, and the error if it failed
+func (m *Metrics) RecordBackend(name string, latency time.Duration, prompt, output string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	usage, ok := m.backends[name]
+	if !ok {
+		usage = &BackendUsage{Name: name}
+		m.backends[name] = usage
+	}
+	usage.Requests++
+	usage.totalLatency += latency
+	usage.PromptTokens += int64((len(prompt) + bytesPerToken - 1) / bytesPerToken)
+	usage.OutputTokens += int64((len(output) + bytesPerToken - 1) / bytesPerToken)
+	if err != nil {
+		usage.Errors++
+	}
+}
+
+// Write remembers the lines that contain "Error", so that log output can be written to Metrics
+func (m *Metrics) Write(p []byte) (int, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(p))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.Contains(line, "Error") {
+			continue
+		}
+		m.mu.Lock()
+		m.errors = append(m.errors, RecentError{Time: time.Now(), Message: line})
+		if len(m.errors) > maxRecentErrors {
+			m.errors = m.errors[len(m.errors)-maxRecentErrors:]
+		}
+		m.mu.Unlock()
+	}
+	return len(p), nil
+}
+
+// Snapshot returns the current metrics, with up to limit topics and trails
+func (m *Metrics) Snapshot(limit int) MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	m.advance(now.Unix())
+	snapshot := MetricsSnapshot{
+		Uptime:       now.Sub(m.started).Round(time.Second),
+		Requests:     m.requests,
+		ServerErrors: m.serverErrors,
+		Generating:   m.generating.Load(),
+		CacheHits:    m.cacheHits,
+		CacheMisses:  m.cacheMisses,
+		TopTopics:    topCounts(m.topics, limit),
+		TopTrails:    topCounts(m.trails, limit),
+		Backends:     []BackendUsage{},
+		Errors:       []RecentError{},
+	}
+	var recent int64
+	for _, count := range m.seconds {
+		recent += count
+	}
+	snapshot.RequestRate = float64(recent) / rateWindow
+	if total := m.cacheHits + m.cacheMisses; total > 0 {
+		snapshot.CacheHitRatio = float64(m.cacheHits) / float64(total)
+	}
+	for _, usage := range m.backends {
+		u := *usage
+		if u.Requests > 0 {
+			u.AverageLatency = (u.totalLatency / time.Duration(u.Requests)).Round(time.Microsecond)
+		}
+		snapshot.Backends = append(snapshot.Backends, u)
+	}
+	sort.Slice(snapshot.Backends, func(i, j int) bool {
+		return snapshot.Backends[i].Name < snapshot.Backends[j].Name
+	})
+	for i := len(m.errors) - 1; i >= 0; i-- {
+		snapshot.Errors = append(snapshot.Errors, m.errors[i])
+	}
+	return snapshot
+}
+
+// topCounts returns up to limit of the names with the highest counts
+func topCounts(counts map[string]int, limit int) []Count {
+	top := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		top = append(top, Count{Name: name, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Name < top[j].Name
+	})
+	if limit > 0 && len(top) > limit {
+		top = top[:limit]
+	}
+	return top
+}
+
+// Metered is a Generator that wraps another Generator and records every request in Metrics
+type Metered struct {
+	Generator Generator
+	Metrics   *Metrics
+}
+
+// NewMetered creates a new Metered that records the requests to g in m
+func NewMetered(g Generator, m *Metrics) *Metered {
+	return &Metered{Generator: g, Metrics: m}
+}
+
+// Name returns the name of the wrapped backend
+func (mg *Metered) Name() string {
+	return mg.Generator.Name()
+}
+
+// Generate calls the wrapped Generator and records the request
+func (mg *Metered) Generate(ctx context.Context, req Request) (string, error) {
+	resp, err := mg.GenerateResponse(ctx, req)
+	return resp.Text, err
+}
+
+// GenerateResponse calls the wrapped Generator and records the request, by the backend that
+// produced the output. Failed requests are recorded by the requested backend, if one was given,
+// or else by the name of the wrapped Generator.
+func (mg *Metered) GenerateResponse(ctx context.Context, req Request) (Response, error) {
+	return mg.record(req, func() (Response, error) {
+		return GenerateResponse(ctx, mg.Generator, req)
+	})
+}
+
+// GenerateResponseStream streams from the wrapped Generator and records the request, like GenerateResponse
+func (mg *Metered) GenerateResponseStream(ctx context.Context, req Request, callback func(chunk string)) (Response, error) {
+	return mg.record(req, func() (Response, error) {
+		return GenerateResponseStream(ctx, mg.Generator, req, callback)
+	})
+}
+
+// record calls generate and records the request
+func (mg *Metered) record(req Request, generate func() (Response, error)) (Response, error) {
+	mg.Metrics.generating.Add(1)
+	defer mg.Metrics.generating.Add(-1)
+	start := time.Now()
+	resp, err := generate()
+	name := resp.Backend
+	if name == "" {
+		name = req.Backend
+	}
+	if name == "" {
+		name = mg.Generator.Name()
+	}
+	mg.Metrics.RecordBackend(name, time.Since(start), req.Prompt, resp.Text, err)
+	return resp, err
+}
+
+// statusRecorder is a http.ResponseWriter that remembers the status code
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader remembers the status code and writes it
+func (sr *statusRecorder) WriteHeader(status int) {
+	sr.status = status
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+// Flush flushes the response, for streamed responses like model pulls
+func (sr *statusRecorder) Flush() {
+	if flusher, ok := sr.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// countRequests is a middleware that records every request in Metrics
+func (s *Server) countRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sr := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sr, r)
+		s.Metrics.RecordRequest(sr.status)
+	})
+}
@@ -0,0 +1,290 @@
+package clickableai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xyproto/ollamaclient/v2"
+)
+
+// ModelInfo describes an installed Ollama model
+type ModelInfo struct {
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// ModelDetails is detailed information about an Ollama model
+type ModelDetails struct {
+	Name              string `json:"name"`
+	Family            string `json:"family"`
+	ParameterSize     string `json:"parameter_size"`
+	QuantizationLevel string `json:"quantization_level"`
+	Format            string `json:"format"`
+	ContextLength     int    `json:"context_length,omitempty"`
+	Parameters        string `json:"parameters"`
+	License           string `json:"license"`
+	OllamaVersion     string `json:"ollama_version"`
+}
+
+// PullProgress is a progress update while a model is being pulled
+type PullProgress struct {
+	Status    string  `json:"status"`
+	Digest    string  `json:"digest,omitempty"`
+	Total     int64   `json:"total,omitempty"`
+	Completed int64   `json:"completed,omitempty"`
+	Percent   float64 `json:"percent"`
+}
+
+// ModelManager lists, pulls and describes the models of an Ollama server
+type ModelManager struct {
+	Config *ollamaclient.Config
+}
+
+// NewModelManager creates a new ModelManager for the given ollamaclient configuration
+func NewModelManager(oc *ollamaclient.Config) *ModelManager {
+	return &ModelManager{Config: oc}
+}
+
+// List returns the installed models, sorted by name
+func (mm *ModelManager) List() ([]ModelInfo, error) {
+	names, modified, sizes, err := mm.Config.List()
+	if err != nil {
+		return nil, err
+	}
+	models := make([]ModelInfo, 0, len(names))
+	for _, name := range names {
+		models = append(models, ModelInfo{Name: name, Size: sizes[name], Modified: modified[name]})
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].Name < models[j].Name
+	})
+	return models, nil
+}
+
+// Has checks if the given model is installed
+func (mm *ModelManager) Has(model string) (bool, error) {
+	return mm.Config.Has(model)
+}
+
+// Info returns detailed information about the given model
+func (mm *ModelManager) Info(model string) (ModelDetails, error) {
+	oc := *mm.Config
+	oc.ModelName = model
+	show, err := oc.GetShowInfo()
+	if err != nil {
+		return ModelDetails{}, err
+	}
+	version, err := oc.Version()
+	if err != nil {
+		return ModelDetails{}, err
+	}
+	return ModelDetails{
+		Name:              model,
+		Family:            show.Details.Family,
+		ParameterSize:     show.Details.ParameterSize,
+		QuantizationLevel: show.Details.QuantizationLevel,
+		Format:            show.Details.Format,
+		ContextLength:     show.ModelInfo.LlamaContextLength,
+		Parameters:        show.Parameters,
+		License:           show.License,
+		OllamaVersion:     version,
+	}, nil
+}
+
+// Pull downloads the given model and calls the callback for every progress update from Ollama
+func (mm *ModelManager) Pull(ctx context.Context, model string, callback func(PullProgress)) error {
+	reqBytes, err := json.Marshal(ollamaclient.PullRequest{Name: model, Stream: true})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, mm.Config.ServerAddr+"/api/pull", bytes.NewReader(reqBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not pull %s: %s", model, resp.Status)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var msg struct {
+			ollamaclient.PullResponse
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&msg); err != nil {
+			return err
+		}
+		if msg.Error != "" {
+			return errors.New(msg.Error)
+		}
+		progress := PullProgress{
+			Status:    msg.Status,
+			Digest:    msg.Digest,
+			Total:     msg.Total,
+			Completed: msg.Completed,
+		}
+		if msg.Total > 0 {
+			progress.Percent = float64(msg.Completed) / float64(msg.Total) * 100
+		}
+		callback(progress)
+		if strings.EqualFold(msg.Status, "success") {
+			return nil
+		}
+	}
+}
+
+// modelCookie is the name of the cookie that holds the model selected for the session
+const modelCookie = "model"
+
+// sessionModel returns the model that is selected for the session, or an empty string for the default model.
+// A "model" parameter, as used by the links from the search page, takes precedence over the session.
+// Models that are not installed are ignored, so that they never become part of the cache keys.
+func (s *Server) sessionModel(r *http.Request) string {
+	if s.Models == nil {
+		return ""
+	}
+	model := strings.TrimSpace(r.FormValue("model"))
+	if model == "" {
+		if cookie, err := r.Cookie(modelCookie); err == nil {
+			model = cookie.Value
+		}
+	}
+	if model == "" || model == s.Models.Config.ModelName {
+		return model
+	}
+	found, err := s.Models.Has(model)
+	if err != nil {
+		log.Println("Error:", err)
+	}
+	if !found {
+		return ""
+	}
+	return model
+}
+
+// checkModel responds with an error and returns false if the "model" parameter is given,
+// but the model is not installed
+func (s *Server) checkModel(w http.ResponseWriter, r *http.Request) bool {
+	model := strings.TrimSpace(r.FormValue("model"))
+	if s.Models == nil || model == "" || s.sessionModel(r) == model {
+		return true
+	}
+	http.Error(w, "Error: The model is not installed: "+model, http.StatusBadRequest)
+	return false
+}
+
+// modelsHandler lists the installed models, with sizes
+func (s *Server) modelsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Models == nil {
+		http.Error(w, "Error: Ollama is not used", http.StatusNotFound)
+		return
+	}
+	models, err := s.Models.List()
+	if err != nil {
+		log.Println("Error:", err)
+		http.Error(w, "Error: Could not list the models", http.StatusBadGateway)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"models":   models,
+		"default":  s.Models.Config.ModelName,
+		"selected": s.sessionModel(r),
+	})
+}
+
+// modelInfoHandler shows information about the model given by the "name" parameter
+func (s *Server) modelInfoHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Models == nil {
+		http.Error(w, "Error: Ollama is not used", http.StatusNotFound)
+		return
+	}
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		name = s.Models.Config.ModelName
+	}
+	info, err := s.Models.Info(name)
+	if err != nil {
+		log.Println("Error:", err)
+		http.Error(w, "Error: Could not get information about "+name, http.StatusBadGateway)
+		return
+	}
+	writeJSON(w, info)
+}
+
+// modelPullHandler pulls the model given by the "name" parameter, and streams the progress
+// as server-sent events. GET is supported, so that EventSource can be used.
+func (s *Server) modelPullHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Models == nil {
+		http.Error(w, "Error: Ollama is not used", http.StatusNotFound)
+		return
+	}
+	if !s.checkAdmin(w, r) {
+		return
+	}
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		http.Error(w, "Error: No model name given", http.StatusBadRequest)
+		return
+	}
+	sse, ok := newEventStream(w)
+	if !ok {
+		http.Error(w, "Error: Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	err := s.Models.Pull(r.Context(), name, func(progress PullProgress) {
+		sse.send("progress", progress)
+	})
+	if err != nil {
+		log.Println("Error:", err)
+		sse.send("error", map[string]string{"error": err.Error()})
+		return
+	}
+	sse.send("done", map[string]string{"name": name})
+}
+
+// sessionModelHandler selects the model for the session, given by the "model" parameter.
+// An empty model selects the default model.
+func (s *Server) sessionModelHandler(w http.ResponseWriter, r *http.Request) {
+	if s.Models == nil {
+		http.Error(w, "Error: Ollama is not used", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Error: Use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	model := strings.TrimSpace(r.FormValue("model"))
+	if model != "" {
+		found, err := s.Models.Has(model)
+		if err != nil {
+			log.Println("Error:", err)
+			http.Error(w, "Error: Could not list the models", http.StatusBadGateway)
+			return
+		}
+		if !found {
+			http.Error(w, "Error: The model is not installed: "+model, http.StatusBadRequest)
+			return
+		}
+	}
+	cookie := &http.Cookie{Name: modelCookie, Value: model, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode}
+	if model == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, cookie)
+	writeJSON(w, map[string]string{"selected": model})
+}
@@ -0,0 +1,127 @@
+package clickableai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/xyproto/ollamaclient/v2"
+)
+
+// suggestTopicsTool is the tool that Ollama models that support tool calling can use to suggest topics.
+// The JSON schema is written out here, since ollamaclient.ToolProperty can not describe arrays of objects.
+var suggestTopicsTool = map[string]any{
+	"type": "function",
+	"function": map[string]any{
+		"name":        "suggest_topics",
+		"description": "Suggest topics that the reader may want to read about next",
+		"parameters": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"topics": map[string]any{
+					"type":        "array",
+					"description": "the suggested topics",
+					"items": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"name":       map[string]any{"type": "string", "description": "the topic, in one to three words"},
+							"reason":     map[string]any{"type": "string", "description": "a short reason for why the topic is interesting"},
+							"difficulty": map[string]any{"type": "string", "description": "how advanced the topic is", "enum": []string{"beginner", "intermediate", "advanced"}},
+							"relation":   map[string]any{"type": "string", "description": "how the topic relates to the current page", "enum": Relations},
+							"confidence": map[string]any{"type": "number", "description": "how relevant the topic is, from 0 to 1"},
+						},
+						"required": []string{"name"},
+					},
+				},
+			},
+			"required": []string{"topics"},
+		},
+	},
+}
+
+// errNoToolSupport is returned by suggestTopics when the model does not support tool calling
+var errNoToolSupport = errors.New("the model does not support tools")
+
+// errNoToolTopics is returned by suggestTopics when the model calls the tool without an array of topics
+var errNoToolTopics = errors.New("the suggest_topics tool was called without an array of topics")
+
+// suggestTopics asks Ollama for topics by using the suggest_topics tool.
+// If the model calls the tool, the topics are returned as a JSON object on the form
+// {"topics": [{"name": ..., "reason": ..., "difficulty": ..., "relation": ..., "confidence": ...}]}.
+// If the model answers with text instead, the text is returned as it is, and if it calls the tool
+// without an array of topics, errNoToolTopics is returned.
+func suggestTopics(ctx context.Context, oc *ollamaclient.Config, prompt string) (string, error) {
+	options := ollamaclient.RequestOptions{Seed: oc.SeedOrNegative}
+	if oc.SeedOrNegative < 0 {
+		options.Temperature = oc.TemperatureIfNegativeSeed
+	}
+	messages := []ollamaclient.Message{}
+	if oc.SystemPrompt != "" {
+		messages = append(messages, ollamaclient.Message{Role: "system", Content: oc.SystemPrompt})
+	}
+	messages = append(messages, ollamaclient.Message{Role: "user", Content: prompt})
+	reqBytes, err := json.Marshal(map[string]any{
+		"model":    oc.ModelName,
+		"messages": messages,
+		"tools":    []any{suggestTopicsTool},
+		"stream":   false,
+		"options":  options,
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oc.ServerAddr+"/api/chat", bytes.NewReader(reqBytes))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := (&http.Client{Timeout: oc.HTTPTimeout}).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var chat struct {
+		Message ollamaclient.MessageResponse `json:"message"`
+		Error   string                       `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&chat); err != nil {
+		return "", fmt.Errorf("%s: %v", resp.Status, err)
+	}
+	if chat.Error != "" {
+		if strings.Contains(chat.Error, "does not support tools") {
+			return "", errNoToolSupport
+		}
+		return "", errors.New(chat.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New(resp.Status)
+	}
+
+	for _, call := range chat.Message.ToolCalls {
+		if call.Function.Name != "suggest_topics" {
+			continue
+		}
+		// Some models give the array as a JSON string instead of as an array
+		topics := call.Function.Arguments["topics"]
+		if s, ok := topics.(string); ok {
+			var decoded any
+			if err := json.Unmarshal([]byte(s), &decoded); err == nil {
+				topics = decoded
+			}
+		}
+		if _, ok := topics.([]any); !ok {
+			return "", errNoToolTopics
+		}
+		output, err := json.Marshal(map[string]any{"topics": topics})
+		if err != nil {
+			return "", err
+		}
+		return string(output), nil
+	}
+	return chat.Message.Content, nil
+}
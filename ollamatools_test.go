@@ -0,0 +1,38 @@
+package clickableai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/xyproto/ollamaclient/v2"
+)
+
+func TestSuggestTopicsArguments(t *testing.T) {
+	for _, tc := range []struct {
+		arguments string
+		want      string
+		err       error
+	}{
+		{`{"topics": [{"name": "Channels"}]}`, `{"topics":[{"name":"Channels"}]}`, nil},
+		{`{"topics": "[{\"name\": \"Channels\"}]"}`, `{"topics":[{"name":"Channels"}]}`, nil},
+		{`{}`, "", errNoToolTopics},
+		{`{"topics": "Channels"}`, "", errNoToolTopics},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"message": {"role": "assistant", "tool_calls": [{"function": {"name": "suggest_topics", "arguments": %s}}]}}`, tc.arguments)
+		}))
+		oc := ollamaclient.New("llama3.2")
+		oc.ServerAddr = server.URL
+		oc.HTTPTimeout = 10 * time.Second
+		output, err := suggestTopics(context.Background(), oc, "Topics for Go")
+		server.Close()
+		if output != tc.want || !errors.Is(err, tc.err) {
+			t.Errorf("arguments %s gave %q and %v, want %q and %v", tc.arguments, output, err, tc.want, tc.err)
+		}
+	}
+}
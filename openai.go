@@ -0,0 +1,262 @@
+package clickableai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/xyproto/env/v2"
+)
+
+const defaultOpenAIBaseURL = "http://localhost:8000/v1"
+
+// OpenAI is a Generator for servers that speak the OpenAI /v1/chat/completions protocol,
+// like vLLM, the llama.cpp server or LocalAI
+type OpenAI struct {
+	BaseURL      string // for example http://localhost:8000/v1
+	Model        string
+	APIKey       string
+	SystemPrompt string
+	HTTPClient   *http.Client
+}
+
+// openAIMessage is a single chat message
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// openAIRequestMessage is a chat message in a request, where the content is
+// either a string or a list of openAIContentPart, for messages with images
+type openAIRequestMessage struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"`
+}
+
+// openAIContentPart is a part of the content of a message with images
+type openAIContentPart struct {
+	Type     string          `json:"type"` // "text" or "image_url"
+	Text     string          `json:"text,omitempty"`
+	ImageURL *openAIImageURL `json:"image_url,omitempty"`
+}
+
+// openAIImageURL is an image, as a data URL
+type openAIImageURL struct {
+	URL string `json:"url"`
+}
+
+// openAIResponseFormat is used for asking for JSON output
+type openAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+// openAIRequest is the request body for /chat/completions
+type openAIRequest struct {
+	Model          string                 `json:"model"`
+	Messages       []openAIRequestMessage `json:"messages"`
+	Temperature    float64                `json:"temperature"`
+	Stream         bool                   `json:"stream,omitempty"`
+	ResponseFormat *openAIResponseFormat  `json:"response_format,omitempty"`
+}
+
+// openAIResponse is the response body for /chat/completions, both for complete responses and streamed chunks
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+		Delta   openAIMessage `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+var errNoChoices = errors.New("openai: the response contained no choices")
+
+// NewOpenAI creates a new OpenAI-compatible Generator.
+// If baseURL or model are empty, OPENAI_BASE_URL and OPENAI_MODEL are used.
+// The API key is read from OPENAI_API_KEY.
+func NewOpenAI(baseURL, model string) *OpenAI {
+	if baseURL == "" {
+		baseURL = env.Str("OPENAI_BASE_URL", defaultOpenAIBaseURL)
+	}
+	if model == "" {
+		model = env.Str("OPENAI_MODEL")
+	}
+	return &OpenAI{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Model:      model,
+		APIKey:     env.Str("OPENAI_API_KEY"),
+		HTTPClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// Name returns the name of this backend
+func (o *OpenAI) Name() string {
+	return "openai"
+}
+
+// Generate sends the request to /chat/completions and returns the generated text
+func (o *OpenAI) Generate(ctx context.Context, req Request) (string, error) {
+	resp, err := o.post(ctx, req, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var completion openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return "", fmt.Errorf("openai: could not decode the response: %v", err)
+	}
+	if completion.Error != nil {
+		return "", fmt.Errorf("openai: %s", completion.Error.Message)
+	}
+	if len(completion.Choices) == 0 {
+		return "", errNoChoices
+	}
+	return strings.TrimSpace(completion.Choices[0].Message.Content), nil
+}
+
+// GenerateStream sends the request to /chat/completions and calls the callback for every
+// chunk of text that is received over server-sent events
+func (o *OpenAI) GenerateStream(ctx context.Context, req Request, callback func(chunk string)) error {
+	resp, err := o.post(ctx, req, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil
+		}
+		var chunk openAIResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("openai: could not decode a streamed chunk: %v", err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("openai: %s", chunk.Error.Message)
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content != "" {
+				callback(choice.Delta.Content)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// post sends a chat completion request and returns the response if the status code is 2xx
+func (o *OpenAI) post(ctx context.Context, req Request, stream bool) (*http.Response, error) {
+	model := o.Model
+	if req.Model != "" {
+		model = req.Model
+	}
+	body := openAIRequest{
+		Model:       model,
+		Temperature: req.Temperature,
+		Stream:      stream,
+	}
+	if o.SystemPrompt != "" {
+		body.Messages = append(body.Messages, openAIRequestMessage{Role: "system", Content: o.SystemPrompt})
+	}
+	if len(req.Images) > 0 {
+		parts := []openAIContentPart{{Type: "text", Text: req.Prompt}}
+		for _, img := range req.Images {
+			url := "data:" + img.MIMEType + ";base64," + img.Base64()
+			parts = append(parts, openAIContentPart{Type: "image_url", ImageURL: &openAIImageURL{URL: url}})
+		}
+		body.Messages = append(body.Messages, openAIRequestMessage{Role: "user", Content: parts})
+	} else {
+		body.Messages = append(body.Messages, openAIRequestMessage{Role: "user", Content: req.Prompt})
+	}
+	if req.JSON {
+		body.ResponseFormat = &openAIResponseFormat{Type: "json_object"}
+	}
+	return o.do(ctx, "/chat/completions", body, stream)
+}
+
+// do sends the given body as JSON to the given path and returns the response if the status code is 2xx
+func (o *OpenAI) do(ctx context.Context, path string, body any, stream bool) (*http.Response, error) {
+	reqBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, o.BaseURL+path, bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if stream {
+		httpReq.Header.Set("Accept", "text/event-stream")
+	}
+	if o.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+o.APIKey)
+	}
+
+	client := o.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		var errResp openAIResponse
+		if json.Unmarshal(msg, &errResp) == nil && errResp.Error != nil {
+			return nil, fmt.Errorf("openai: %s: %s", resp.Status, errResp.Error.Message)
+		}
+		return nil, fmt.Errorf("openai: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// openAIEmbeddingsResponse is the response body for /embeddings
+type openAIEmbeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed returns one embedding vector per text, by using the /embeddings endpoint with the given model
+func (o *OpenAI) Embed(ctx context.Context, model string, texts []string) ([][]float64, error) {
+	resp, err := o.do(ctx, "/embeddings", map[string]any{"model": model, "input": texts}, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var embeddings openAIEmbeddingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embeddings); err != nil {
+		return nil, fmt.Errorf("openai: could not decode the response: %v", err)
+	}
+	vectors := make([][]float64, len(texts))
+	for _, d := range embeddings.Data {
+		if d.Index >= 0 && d.Index < len(vectors) {
+			vectors[d.Index] = d.Embedding
+		}
+	}
+	for _, v := range vectors {
+		if len(v) == 0 {
+			return nil, errors.New("openai: an embedding is missing from the response")
+		}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,147 @@
+package clickableai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newOpenAIStandIn starts a stand-in for an OpenAI-compatible server, that decodes every
+// request to /chat/completions and passes it to the given handler
+func newOpenAIStandIn(t *testing.T, handler func(w http.ResponseWriter, body openAIRequest)) *OpenAI {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/chat/completions" {
+			http.Error(w, "unexpected request: "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+		}
+		var body openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("could not decode the request: %v", err)
+		}
+		handler(w, body)
+	}))
+	t.Cleanup(server.Close)
+	o := NewOpenAI(server.URL+"/v1/", "test-model")
+	o.APIKey = "secret"
+	return o
+}
+
+func TestOpenAIGenerate(t *testing.T) {
+	o := newOpenAIStandIn(t, func(w http.ResponseWriter, body openAIRequest) {
+		if body.Model != "test-model" || body.Stream || body.ResponseFormat != nil {
+			t.Errorf("unexpected request: %+v", body)
+		}
+		if len(body.Messages) != 1 || body.Messages[0].Role != "user" || body.Messages[0].Content != "Explain Go" {
+			t.Errorf("unexpected messages: %+v", body.Messages)
+		}
+		fmt.Fprint(w, `{"choices": [{"message": {"role": "assistant", "content": "  # Go\n"}}]}`)
+	})
+	output, err := o.Generate(context.Background(), Request{Prompt: "Explain Go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output != "# Go" {
+		t.Errorf("Generate = %q, want %q", output, "# Go")
+	}
+}
+
+func TestOpenAIGenerateStream(t *testing.T) {
+	o := newOpenAIStandIn(t, func(w http.ResponseWriter, body openAIRequest) {
+		if !body.Stream {
+			t.Error("stream was not requested")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{"Hello", ", ", "world"} {
+			fmt.Fprintf(w, "data: {\"choices\": [{\"delta\": {\"content\": %q}}]}\n\n", chunk)
+		}
+		fmt.Fprint(w, ": a comment\n\ndata: [DONE]\n\n")
+		fmt.Fprint(w, "data: {\"choices\": [{\"delta\": {\"content\": \"after done\"}}]}\n\n")
+	})
+	var chunks []string
+	err := o.GenerateStream(context.Background(), Request{Prompt: "Greet"}, func(chunk string) {
+		chunks = append(chunks, chunk)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(chunks, "|"); got != "Hello|, |world" {
+		t.Errorf("chunks = %q, want %q", got, "Hello|, |world")
+	}
+}
+
+func TestOpenAITopicsAskForJSON(t *testing.T) {
+	o := newOpenAIStandIn(t, func(w http.ResponseWriter, body openAIRequest) {
+		if body.ResponseFormat == nil || body.ResponseFormat.Type != "json_object" {
+			t.Errorf("response_format = %+v, want json_object", body.ResponseFormat)
+		}
+		content := `{"topics": [{"name": "Goroutines", "relation": "deeper"}, {"name": "Channels", "relation": "related"}]}`
+		data, _ := json.Marshal(map[string]any{"choices": []any{map[string]any{"message": map[string]string{"content": content}}}})
+		w.Write(data)
+	})
+	topics, err := GenerateTopics(context.Background(), o, "Topics for Go.", 0.5, []string{"Go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topics) != 2 || topics[0].Name != "Goroutines" || topics[1].Name != "Channels" {
+		t.Errorf("topics = %+v", topics)
+	}
+}
+
+func TestOpenAIErrorStatus(t *testing.T) {
+	for _, tc := range []struct {
+		status int
+		body   string
+		want   string
+	}{
+		{http.StatusUnauthorized, `{"error": {"message": "invalid API key"}}`, "invalid API key"},
+		{http.StatusServiceUnavailable, "the model is loading", "the model is loading"},
+	} {
+		o := newOpenAIStandIn(t, func(w http.ResponseWriter, body openAIRequest) {
+			w.WriteHeader(tc.status)
+			fmt.Fprint(w, tc.body)
+		})
+		if _, err := o.Generate(context.Background(), Request{Prompt: "Explain Go"}); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Generate with status %d: error = %v, want it to contain %q", tc.status, err, tc.want)
+		}
+		if err := o.GenerateStream(context.Background(), Request{Prompt: "Explain Go"}, func(string) {}); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("GenerateStream with status %d: error = %v, want it to contain %q", tc.status, err, tc.want)
+		}
+	}
+}
+
+func TestOpenAIErrorBody(t *testing.T) {
+	// Some servers report errors with a 200 OK status
+	o := newOpenAIStandIn(t, func(w http.ResponseWriter, body openAIRequest) {
+		fmt.Fprint(w, `{"error": {"message": "the context is too long"}}`)
+	})
+	if _, err := o.Generate(context.Background(), Request{Prompt: "Explain Go"}); err == nil || !strings.Contains(err.Error(), "the context is too long") {
+		t.Errorf("Generate: error = %v, want the message from the server", err)
+	}
+}
+
+func TestOpenAIEmbed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		// The embeddings are given out of order, and must be sorted by their index
+		fmt.Fprint(w, `{"data": [{"index": 1, "embedding": [0, 1]}, {"index": 0, "embedding": [1, 0]}]}`)
+	}))
+	defer server.Close()
+	vectors, err := NewOpenAI(server.URL+"/v1", "test-model").Embed(context.Background(), "embedder", []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 1 || vectors[1][1] != 1 {
+		t.Errorf("vectors = %v", vectors)
+	}
+}
@@ -0,0 +1,163 @@
+package clickableai
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// maxShortLinks is the maximum number of short links that are remembered
+const maxShortLinks = 100000
+
+// maxDescriptionLength is the maximum number of bytes in the description of a link preview
+const maxDescriptionLength = 200
+
+// shortIDEncoding encodes short IDs with lowercase letters and digits
+var shortIDEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// PageMeta is the metadata of a page that is opened by its URL, for the Open Graph tags of link previews
+type PageMeta struct {
+	Title       string
+	Description string
+	URL         string // the absolute permalink of the page
+}
+
+// Permalink returns the canonical URL of the given page, like /t/Go/Concurrency/Channels?lang=en.
+// Keywords with slashes or other special characters are escaped.
+func Permalink(id PageID) string {
+	values := url.Values{"lang": {id.Lang}}
+	if id.Model != "" {
+		values.Set("model", id.Model)
+	}
+	if len(id.Trail) == 0 {
+		return "/?" + values.Encode()
+	}
+	segments := make([]string, len(id.Trail))
+	for i, keyword := range id.Trail {
+		segments[i] = url.PathEscape(keyword)
+	}
+	return "/t/" + strings.Join(segments, "/") + "?" + values.Encode()
+}
+
+// ShortLinks is a concurrency-safe map from short IDs to pages, for links like /p/abcd2345
+type ShortLinks struct {
+	mu    sync.RWMutex
+	pages map[string]PageID
+}
+
+// NewShortLinks creates a new and empty ShortLinks
+func NewShortLinks() *ShortLinks {
+	return &ShortLinks{pages: make(map[string]PageID)}
+}
+
+// Add returns the short link of the given page, like /p/abcd2345. The short ID is a hash of the
+// page ID, so a page always gets the same short link. Returns an empty string if the short ID
+// is taken by another page, or if the maximum number of short links has been reached.
+func (sl *ShortLinks) Add(id PageID) string {
+	sum := sha256.Sum256([]byte(id.Key()))
+	short := shortIDEncoding.EncodeToString(sum[:5])
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	if existing, ok := sl.pages[short]; ok {
+		if existing.Key() != id.Key() {
+			return ""
+		}
+		return "/p/" + short
+	}
+	if len(sl.pages) >= maxShortLinks {
+		return ""
+	}
+	id.Trail = append([]string{}, id.Trail...)
+	sl.pages[short] = id
+	return "/p/" + short
+}
+
+// Get returns the page with the given short ID, if there is one
+func (sl *ShortLinks) Get(short string) (PageID, bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	id, ok := sl.pages[short]
+	return id, ok
+}
+
+// trailHandler opens the page for the trail in the path, like /t/Go/Concurrency/Channels
+func (s *Server) trailHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	var trail []string
+	for _, segment := range strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), "/t/"), "/") {
+		keyword, err := url.PathUnescape(segment)
+		if err != nil {
+			http.Error(w, "Error: Invalid keyword in the path", http.StatusBadRequest)
+			return
+		}
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			trail = append(trail, keyword)
+		}
+	}
+	if len(trail) == 0 {
+		http.Redirect(w, r, "/", http.StatusFound)
+		return
+	}
+	if !s.checkTrail(w, trail) {
+		return
+	}
+	s.renderPage(w, r, PageID{Lang: NegotiateLanguage(r), Model: s.sessionModel(r), Trail: trail})
+}
+
+// shortLinkHandler opens the page for the short ID in the path, like /p/abcd2345
+func (s *Server) shortLinkHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := s.ShortLinks.Get(strings.TrimPrefix(r.URL.Path, "/p/"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	s.renderPage(w, r, id)
+}
+
+// renderPage renders the main page, which opens the given page and its trail. If the page is cached,
+// the table of contents and the description of the link preview are taken from it. If not, the page
+// is generated when it is opened.
+func (s *Server) renderPage(w http.ResponseWriter, r *http.Request, id PageID) {
+	data := s.pageData(w, r)
+	data.Lang = id.Lang
+	data.Trail = id.Trail
+	data.Model = id.Model
+	data.Meta = &PageMeta{
+		Title:       strings.Join(id.Trail, " → "),
+		Description: strings.Join(id.Trail, " → "),
+		URL:         s.baseURL(r) + Permalink(id),
+	}
+	if page, ok := s.Cache.Get(id); ok && page.Markdown != "" {
+		data.TOC = TableOfContents(page.Markdown)
+		data.Meta.Description = description(markdownText(page.Markdown))
+	}
+	s.render(w, r, s.tmpl, data)
+}
+
+// baseURL returns the public URL of the server, without a trailing slash. If it is not configured,
+// it is taken from the request, and from the X-Forwarded-Proto header of a reverse proxy.
+func (s *Server) baseURL(r *http.Request) string {
+	if s.PublicURL != "" {
+		return strings.TrimSuffix(s.PublicURL, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// description shortens a text to at most maxDescriptionLength bytes, at a word boundary
+func description(text string) string {
+	if len(text) <= maxDescriptionLength {
+		return text
+	}
+	text = truncate(text, maxDescriptionLength-len("…"))
+	if cut := strings.LastIndex(text, " "); cut > 0 {
+		text = text[:cut]
+	}
+	return text + "…"
+}
@@ -0,0 +1,130 @@
+package clickableai
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/xyproto/ollamaclient/v2"
+)
+
+// PersonaModelName is the name of the Ollama model that is created from the embedded Modelfile template.
+// The tag of the model is a hash of the Modelfile, so that a changed template gives a new model.
+const PersonaModelName = "clickableai-persona"
+
+// PersonaModelfile returns the Modelfile for the persona model, based on the given base model.
+// The diagram prompt and the main prompt are added to the system prompt, unless they are empty,
+// and the main prompt is only added if it is not the default one, which the system prompt covers.
+func PersonaModelfile(baseModel, mainPrompt, diagramPrompt string) (string, error) {
+	t, err := template.New("Modelfile").Parse(string(Asset("Modelfile.tmpl")))
+	if err != nil {
+		return "", err
+	}
+	if mainPrompt == DefaultMainPrompt {
+		mainPrompt = ""
+	}
+	data := struct{ BaseModel, MainPrompt, DiagramPrompt string }{
+		BaseModel:     baseModel,
+		MainPrompt:    modelfileText(mainPrompt),
+		DiagramPrompt: modelfileText(diagramPrompt),
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// modelfileText makes a prompt fit in a triple-quoted Modelfile string
+func modelfileText(prompt string) string {
+	return strings.TrimSpace(strings.ReplaceAll(prompt, `"""`, `"`))
+}
+
+// PersonaModel returns the full name of the persona model for the given Modelfile, like "clickableai-persona:0123456789ab"
+func PersonaModel(modelfile string) string {
+	return fmt.Sprintf("%s:%x", PersonaModelName, sha256.Sum256([]byte(modelfile)))[:len(PersonaModelName)+1+12]
+}
+
+// EnsurePersona makes sure that the persona model for the given base model and prompts is present, and returns its name.
+// If create is false, the persona model is only created if an older version of it is present, and an empty
+// string is returned if there is no persona model. Older versions of the persona model are removed.
+func EnsurePersona(oc *ollamaclient.Config, baseModel, mainPrompt, diagramPrompt string, create bool) (string, error) {
+	modelfile, err := PersonaModelfile(baseModel, mainPrompt, diagramPrompt)
+	if err != nil {
+		return "", err
+	}
+	name := PersonaModel(modelfile)
+
+	names, _, _, err := oc.List()
+	if err != nil {
+		return "", err
+	}
+	var outdated []string
+	for _, existing := range names {
+		if existing == name {
+			return name, nil
+		}
+		if strings.HasPrefix(existing, PersonaModelName+":") {
+			outdated = append(outdated, existing)
+		}
+	}
+	if !create && len(outdated) == 0 {
+		return "", nil
+	}
+
+	base := *oc
+	base.ModelName = baseModel
+	if err := base.PullIfNeeded(); err != nil {
+		return "", fmt.Errorf("could not pull %s: %v", baseModel, err)
+	}
+	log.Printf("Creating %s from %s\n", name, baseModel)
+	if err := createModel(oc, name, modelfile); err != nil {
+		return "", fmt.Errorf("could not create %s: %v", name, err)
+	}
+	if found, err := oc.Has(name); err != nil {
+		return "", err
+	} else if !found {
+		return "", fmt.Errorf("could not create %s: the model was not found after creating it", name)
+	}
+	for _, old := range outdated {
+		log.Printf("Removing the outdated %s\n", old)
+		if err := oc.DeleteModel(old); err != nil {
+			log.Println("Error:", err)
+		}
+	}
+	return name, nil
+}
+
+// createModel creates a model from a Modelfile and waits until Ollama is done.
+// ollamaclient.CreateModel does not wait for the model to be created, and does not report errors.
+func createModel(oc *ollamaclient.Config, name, modelfile string) error {
+	reqBytes, err := json.Marshal(map[string]any{"name": name, "modelfile": modelfile, "stream": false})
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(oc.ServerAddr+"/api/create", "application/json", bytes.NewReader(reqBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var msg struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return fmt.Errorf("%s: %v", resp.Status, err)
+	}
+	if msg.Error != "" {
+		return errors.New(msg.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
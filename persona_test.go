@@ -0,0 +1,23 @@
+package clickableai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPersonaModelfilePrompts(t *testing.T) {
+	modelfile, err := PersonaModelfile("gemma2:2b", DefaultMainPrompt, "Add \"\"\"diagrams\"\"\".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(modelfile, DefaultMainPrompt) || !strings.Contains(modelfile, `Add "diagrams".`) {
+		t.Errorf("unexpected Modelfile:\n%s", modelfile)
+	}
+	custom, err := PersonaModelfile("gemma2:2b", "Write for beginners.", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(custom, "Write for beginners.") || PersonaModel(custom) == PersonaModel(modelfile) {
+		t.Errorf("the custom main prompt is missing:\n%s", custom)
+	}
+}
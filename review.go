@@ -0,0 +1,83 @@
+package clickableai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Claim is a factual claim in a generated page, as found by the reviewer
+type Claim struct {
+	Text      string `json:"text"` // quoted from the page
+	Uncertain bool   `json:"uncertain"`
+	Reason    string `json:"reason,omitempty"`  // why the claim is uncertain
+	Sources   []int  `json:"sources,omitempty"` // citations, as 1-based indexes into the sources of the page
+}
+
+// Review is the result of the second pass over a generated page
+type Review struct {
+	Claims   []Claim   `json:"claims"`
+	Backend  string    `json:"backend"`
+	Reviewed time.Time `json:"reviewed"`
+}
+
+// reviewPrompt asks the reviewer for the claims of a page, as JSON
+const reviewPrompt = `You are reviewing a page of technical documentation. List the most important factual claims that the page makes, up to 10.
+For each claim, quote the sentence from the page exactly as it is written, mark it as uncertain if it may be wrong or outdated, and explain why in a few words.
+`
+
+// reviewJSONPrompt asks for claims as a JSON object that parseReview can parse
+const reviewJSONPrompt = `Output a JSON object on the form {"claims": [{"text": "the quoted sentence", "uncertain": false, "reason": "", "sources": [1]}]} and nothing else.`
+
+// ReviewMarkdown asks the generator to list the factual claims of the Markdown document, flag the
+// uncertain ones and cite the given documentation passages that support them, if there are any
+func ReviewMarkdown(ctx context.Context, g Generator, markdown string, sources []Passage) (Review, error) {
+	var sb strings.Builder
+	sb.WriteString(reviewPrompt)
+	if len(sources) > 0 {
+		sb.WriteString("For each claim, list the numbers of the sources below that support it.\n\nSources:\n")
+		for i, p := range sources {
+			fmt.Fprintf(&sb, "\n[%d] %s (%s):\n%s\n", i+1, p.Heading, p.Source, p.Text)
+		}
+	}
+	sb.WriteString("\nThe page:\n\n" + markdown + "\n\n" + reviewJSONPrompt)
+
+	resp, err := GenerateResponse(ctx, g, Request{Prompt: sb.String(), JSON: true})
+	if err != nil {
+		return Review{}, err
+	}
+	claims, err := parseReview(resp.Text, len(sources))
+	if err != nil {
+		return Review{}, fmt.Errorf("could not parse the review: %v", err)
+	}
+	return Review{Claims: claims, Backend: resp.Backend, Reviewed: time.Now()}, nil
+}
+
+// parseReview parses the output of the reviewer, and removes claims without text and citations of unknown sources
+func parseReview(output string, numSources int) ([]Claim, error) {
+	var obj struct {
+		Claims []Claim `json:"claims"`
+	}
+	if err := json.Unmarshal([]byte(trimJSONFence(output)), &obj); err != nil {
+		return nil, err
+	}
+	claims := make([]Claim, 0, len(obj.Claims))
+	for _, claim := range obj.Claims {
+		claim.Text = strings.TrimSpace(claim.Text)
+		if claim.Text == "" {
+			continue
+		}
+		var sources []int
+		for _, n := range claim.Sources {
+			if n >= 1 && n <= numSources {
+				sources = append(sources, n)
+			}
+		}
+		claim.Sources = sources
+		claim.Reason = strings.TrimSpace(claim.Reason)
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
@@ -0,0 +1,351 @@
+package clickableai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoBackend is returned by Router when no backend is available for a request
+var ErrNoBackend = errors.New("no backend is available")
+
+// Response is generated text together with the name of the backend that produced it
+type Response struct {
+	Text    string
+	Backend string
+}
+
+// ResponseGenerator is a Generator that can report which backend produced the output
+type ResponseGenerator interface {
+	Generator
+	GenerateResponse(ctx context.Context, req Request) (Response, error)
+}
+
+// GenerateResponse generates text with the given Generator and reports which backend produced it
+func GenerateResponse(ctx context.Context, g Generator, req Request) (Response, error) {
+	if rg, ok := g.(ResponseGenerator); ok {
+		return rg.GenerateResponse(ctx, req)
+	}
+	text, err := g.Generate(ctx, req)
+	if err != nil {
+		return Response{}, err
+	}
+	return Response{Text: text, Backend: g.Name()}, nil
+}
+
+// ResponseStreamGenerator is a Generator that can deliver the output chunk by chunk and report
+// which backend produced it
+type ResponseStreamGenerator interface {
+	Generator
+	GenerateResponseStream(ctx context.Context, req Request, callback func(chunk string)) (Response, error)
+}
+
+// GenerateResponseStream generates text with the given Generator, calls the callback for every chunk
+// of text and reports which backend produced it. Generators that can not stream deliver all of the
+// text as a single chunk.
+func GenerateResponseStream(ctx context.Context, g Generator, req Request, callback func(chunk string)) (Response, error) {
+	if rg, ok := g.(ResponseStreamGenerator); ok {
+		return rg.GenerateResponseStream(ctx, req, callback)
+	}
+	sg, ok := g.(StreamGenerator)
+	if !ok {
+		resp, err := GenerateResponse(ctx, g, req)
+		if err == nil {
+			callback(resp.Text)
+		}
+		return resp, err
+	}
+	var sb strings.Builder
+	err := sg.GenerateStream(ctx, req, func(chunk string) {
+		sb.WriteString(chunk)
+		callback(chunk)
+	})
+	if err != nil {
+		return Response{}, err
+	}
+	return Response{Text: strings.TrimSpace(sb.String()), Backend: g.Name()}, nil
+}
+
+// RoutedBackend is a backend that is used by a Router, together with its health
+type RoutedBackend struct {
+	Generator Generator
+	Weight    int // relative weight for load balancing, 0 means only use as a fallback
+
+	consecutiveFailures int
+	openUntil           time.Time
+	requests            int64
+	failures            int64
+	lastError           string
+	totalLatency        time.Duration
+}
+
+// BackendHealth is a snapshot of the health of a routed backend
+type BackendHealth struct {
+	Name                string
+	Weight              int
+	Available           bool
+	ConsecutiveFailures int
+	Requests            int64
+	Failures            int64
+	LastError           string
+	AverageLatency      time.Duration
+}
+
+// Router is a Generator that routes requests to several backends.
+// Healthy backends with a weight are load balanced, and the remaining
+// backends are tried in order if a backend fails or times out.
+// A backend that fails too many times in a row is skipped for a while.
+type Router struct {
+	Backends         []*RoutedBackend
+	Timeout          time.Duration // per backend, 0 means no timeout
+	FailureThreshold int           // consecutive failures before a backend is skipped
+	Cooldown         time.Duration // how long a failing backend is skipped
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewRouter creates a new Router that tries the given backends in order, with no load balancing
+func NewRouter(backends ...Generator) *Router {
+	router := &Router{
+		Timeout:          2 * time.Minute,
+		FailureThreshold: 3,
+		Cooldown:         time.Minute,
+		rng:              rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, g := range backends {
+		router.Add(g, 0)
+	}
+	return router
+}
+
+// Add adds a backend with the given load balancing weight
+func (router *Router) Add(g Generator, weight int) {
+	router.mu.Lock()
+	defer router.mu.Unlock()
+	router.Backends = append(router.Backends, &RoutedBackend{Generator: g, Weight: weight})
+}
+
+// Name returns the name of this backend
+func (router *Router) Name() string {
+	router.mu.Lock()
+	defer router.mu.Unlock()
+	names := make([]string, len(router.Backends))
+	for i, b := range router.Backends {
+		names[i] = b.Generator.Name()
+	}
+	return "router(" + strings.Join(names, ",") + ")"
+}
+
+// Generate generates text with the first backend that succeeds
+func (router *Router) Generate(ctx context.Context, req Request) (string, error) {
+	resp, err := router.GenerateResponse(ctx, req)
+	return resp.Text, err
+}
+
+// GenerateResponse generates text with the first backend that succeeds, and reports which backend that was.
+// If req.Backend is set, only that backend is used.
+func (router *Router) GenerateResponse(ctx context.Context, req Request) (Response, error) {
+	candidates, err := router.candidates(req.Backend)
+	if err != nil {
+		return Response{}, err
+	}
+	var errs []string
+	for _, b := range candidates {
+		if err := ctx.Err(); err != nil {
+			return Response{}, err
+		}
+		resp, err := router.try(ctx, b, req)
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, b.Generator.Name()+": "+err.Error())
+	}
+	return Response{}, fmt.Errorf("%w: %s", ErrNoBackend, strings.Join(errs, "; "))
+}
+
+// GenerateResponseStream streams text from the first backend that succeeds, and reports which
+// backend that was. A backend that fails after some of the text has been delivered is not
+// replaced by the next one, since the text can not be taken back.
+func (router *Router) GenerateResponseStream(ctx context.Context, req Request, callback func(chunk string)) (Response, error) {
+	candidates, err := router.candidates(req.Backend)
+	if err != nil {
+		return Response{}, err
+	}
+	var errs []string
+	for _, b := range candidates {
+		if err := ctx.Err(); err != nil {
+			return Response{}, err
+		}
+		delivered := false
+		resp, err := router.tryStream(ctx, b, req, func(chunk string) {
+			delivered = true
+			callback(chunk)
+		})
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, b.Generator.Name()+": "+err.Error())
+		if delivered {
+			break
+		}
+	}
+	return Response{}, fmt.Errorf("%w: %s", ErrNoBackend, strings.Join(errs, "; "))
+}
+
+// Health returns a snapshot of the health of all backends
+func (router *Router) Health() []BackendHealth {
+	router.mu.Lock()
+	defer router.mu.Unlock()
+	now := time.Now()
+	health := make([]BackendHealth, len(router.Backends))
+	for i, b := range router.Backends {
+		health[i] = BackendHealth{
+			Name:                b.Generator.Name(),
+			Weight:              b.Weight,
+			Available:           now.After(b.openUntil),
+			ConsecutiveFailures: b.consecutiveFailures,
+			Requests:            b.requests,
+			Failures:            b.failures,
+			LastError:           b.lastError,
+		}
+		if b.requests > 0 {
+			health[i].AverageLatency = b.totalLatency / time.Duration(b.requests)
+		}
+	}
+	return health
+}
+
+// SetDefault makes the backend with the given name the one that is tried first, with the other
+// backends as fallbacks in their configured order. The load balancing weights are replaced.
+func (router *Router) SetDefault(name string) error {
+	router.mu.Lock()
+	defer router.mu.Unlock()
+	for i, b := range router.Backends {
+		if b.Generator.Name() != name {
+			continue
+		}
+		backends := append([]*RoutedBackend{b}, router.Backends[:i]...)
+		router.Backends = append(backends, router.Backends[i+1:]...)
+		for _, other := range router.Backends {
+			other.Weight = 0
+		}
+		b.Weight = 1
+		return nil
+	}
+	return fmt.Errorf("%w: unknown backend %q", ErrNoBackend, name)
+}
+
+// try generates text with a single backend, with a timeout, and records the outcome
+func (router *Router) try(ctx context.Context, b *RoutedBackend, req Request) (Response, error) {
+	if router.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, router.Timeout)
+		defer cancel()
+	}
+	req.Backend = ""
+
+	type result struct {
+		resp Response
+		err  error
+	}
+	done := make(chan result, 1)
+	start := time.Now()
+	go func() {
+		resp, err := GenerateResponse(ctx, b.Generator, req)
+		done <- result{resp, err}
+	}()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-ctx.Done():
+		res.err = ctx.Err()
+	}
+	router.record(b, time.Since(start), res.err)
+	return res.resp, res.err
+}
+
+// tryStream streams text from a single backend, with a timeout, and records the outcome.
+// Unlike try, it waits for the backend to return, so that no chunks are delivered afterwards.
+func (router *Router) tryStream(ctx context.Context, b *RoutedBackend, req Request, callback func(chunk string)) (Response, error) {
+	if router.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, router.Timeout)
+		defer cancel()
+	}
+	req.Backend = ""
+	start := time.Now()
+	resp, err := GenerateResponseStream(ctx, b.Generator, req, callback)
+	router.record(b, time.Since(start), err)
+	return resp, err
+}
+
+// record updates the health of a backend after a request
+func (router *Router) record(b *RoutedBackend, latency time.Duration, err error) {
+	router.mu.Lock()
+	defer router.mu.Unlock()
+	b.requests++
+	b.totalLatency += latency
+	if err == nil {
+		b.consecutiveFailures = 0
+		return
+	}
+	b.failures++
+	b.consecutiveFailures++
+	b.lastError = err.Error()
+	if router.FailureThreshold > 0 && b.consecutiveFailures >= router.FailureThreshold {
+		b.openUntil = time.Now().Add(router.Cooldown)
+	}
+}
+
+// candidates returns the backends to try, in order.
+// One of the available backends with a weight is picked first, by weighted random selection,
+// followed by the remaining available backends in the configured order.
+func (router *Router) candidates(selected string) ([]*RoutedBackend, error) {
+	router.mu.Lock()
+	defer router.mu.Unlock()
+
+	if selected != "" {
+		for _, b := range router.Backends {
+			if b.Generator.Name() == selected {
+				return []*RoutedBackend{b}, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: unknown backend %q", ErrNoBackend, selected)
+	}
+
+	now := time.Now()
+	var available []*RoutedBackend
+	totalWeight := 0
+	for _, b := range router.Backends {
+		if now.After(b.openUntil) {
+			available = append(available, b)
+			totalWeight += b.Weight
+		}
+	}
+	if len(available) == 0 {
+		// Every circuit is open, so try all backends rather than failing outright
+		available = append(available, router.Backends...)
+	}
+	if len(available) == 0 {
+		return nil, ErrNoBackend
+	}
+	if totalWeight == 0 {
+		return available, nil
+	}
+
+	pick := router.rng.Intn(totalWeight)
+	for i, b := range available {
+		if pick < b.Weight {
+			ordered := append([]*RoutedBackend{b}, available[:i]...)
+			return append(ordered, available[i+1:]...), nil
+		}
+		pick -= b.Weight
+	}
+	return available, nil
+}
@@ -0,0 +1,293 @@
+package clickableai
+
+import (
+	"context"
+	"html"
+	"html/template"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SearchResult is a page that matches a search query
+type SearchResult struct {
+	Trail   []string      `json:"trail"`
+	Lang    string        `json:"lang"`
+	Model   string        `json:"model,omitempty"`
+	Title   string        `json:"title"`
+	Snippet template.HTML `json:"snippet"` // HTML, with the matching words in <mark> tags
+	Score   float64       `json:"score"`
+	URL     string        `json:"url"` // opens the page and its trail
+}
+
+// searchDoc is an indexed page
+type searchDoc struct {
+	id    PageID
+	title string
+	text  string // the Markdown, as plain text
+}
+
+// SearchIndex is a concurrency-safe full-text index of generated pages. If Embedder is set,
+// the pages are also embedded, and the results are ranked by both words and meaning.
+type SearchIndex struct {
+	Embedder Embedder
+
+	mu      sync.RWMutex
+	docs    map[string]*searchDoc
+	terms   *termIndex
+	vectors map[string][]float64
+}
+
+// titleWeight is how many times the words of the trail count, compared to the words of the page
+const titleWeight = 3
+
+// maxEmbeddedText is the maximum number of bytes of a page that is embedded
+const maxEmbeddedText = 2000
+
+// NewSearchIndex creates a new and empty SearchIndex. The Embedder may be nil.
+func NewSearchIndex(e Embedder) *SearchIndex {
+	return &SearchIndex{
+		Embedder: e,
+		docs:     make(map[string]*searchDoc),
+		terms:    newTermIndex(),
+		vectors:  make(map[string][]float64),
+	}
+}
+
+// Len returns the number of indexed pages
+func (si *SearchIndex) Len() int {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return len(si.docs)
+}
+
+// Add indexes the given page, replacing the page with the same ID if it is already indexed
+func (si *SearchIndex) Add(ctx context.Context, page Page) {
+	doc, vector := si.prepare(ctx, page)
+	si.insert(page.Key(), doc, vector)
+}
+
+// prepare returns the document for the given page, and its embedding vector if there is an Embedder
+func (si *SearchIndex) prepare(ctx context.Context, page Page) (*searchDoc, []float64) {
+	doc := &searchDoc{
+		id:    page.PageID,
+		title: strings.Join(page.Trail, " → "),
+		text:  markdownText(page.Markdown),
+	}
+	doc.id.Trail = append([]string{}, page.Trail...)
+	if si.Embedder == nil {
+		return doc, nil
+	}
+	vectors, err := si.Embedder.Embed(ctx, []string{truncate(doc.title+"\n"+doc.text, maxEmbeddedText)})
+	if err != nil {
+		log.Println("Error:", err)
+		return doc, nil
+	}
+	return doc, vectors[0]
+}
+
+// insert adds a prepared document to the index, replacing the document with the same key
+func (si *SearchIndex) insert(key string, doc *searchDoc, vector []float64) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.docs[key] = doc
+	si.terms.add(key, weightedTerms(doc.title, doc.text, titleWeight))
+	if vector != nil {
+		si.vectors[key] = vector
+	} else {
+		delete(si.vectors, key)
+	}
+}
+
+// Remove removes the page with the given ID from the index
+func (si *SearchIndex) Remove(id PageID) {
+	key := id.Key()
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	delete(si.docs, key)
+	delete(si.vectors, key)
+	si.terms.remove(key)
+}
+
+// Search returns up to limit pages that match the query, with the best matches first.
+// The words are ranked with BM25. If there is an Embedder, the similarity of meaning counts
+// as much as the words, and pages without matching words can also be found.
+func (si *SearchIndex) Search(ctx context.Context, query string, limit int) []SearchResult {
+	terms := searchTerms(query)
+	queryVector := embedQuery(ctx, si.Embedder, query)
+
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	scores := hybridScores(si.terms.scores(terms), queryVector, si.vectors)
+	keys := rankedKeys(scores, limit)
+	results := make([]SearchResult, 0, len(keys))
+	for _, key := range keys {
+		doc := si.docs[key]
+		results = append(results, SearchResult{
+			Trail:   doc.id.Trail,
+			Lang:    doc.id.Lang,
+			Model:   doc.id.Model,
+			Title:   doc.title,
+			Snippet: snippet(doc.text, terms),
+			Score:   scores[key],
+			URL:     Permalink(doc.id),
+		})
+	}
+	return results
+}
+
+// embedQuery returns the embedding of the query, or nil if there is no Embedder or if it fails
+func embedQuery(ctx context.Context, e Embedder, query string) []float64 {
+	if e == nil || strings.TrimSpace(query) == "" {
+		return nil
+	}
+	vectors, err := e.Embed(ctx, []string{query})
+	if err != nil {
+		log.Println("Error:", err)
+		return nil
+	}
+	return vectors[0]
+}
+
+// stopWords are common English words that are not indexed
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"that": true, "the": true, "this": true, "to": true, "with": true,
+}
+
+// searchTerms returns the normalized words of the text, without stop words
+func searchTerms(text string) []string {
+	var terms []string
+	for _, word := range splitWords(text) {
+		if term := normalizeWord(word); len(term) > 1 && !stopWords[term] {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+var (
+	markdownLinkRegexp   = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	markdownSyntaxRegexp = regexp.MustCompile("(?m)^\\s*(#+|>|[*+-]|\\d+\\.|```\\w*|\\|?[-:| ]+\\|)\\s*|[*_`|]")
+	whitespaceRegexp     = regexp.MustCompile(`\s+`)
+)
+
+// markdownText returns the text of a Markdown document, without most of the Markdown syntax
+func markdownText(markdown string) string {
+	text := markdownLinkRegexp.ReplaceAllString(markdown, "$1")
+	text = markdownSyntaxRegexp.ReplaceAllString(text, " ")
+	return strings.TrimSpace(whitespaceRegexp.ReplaceAllString(text, " "))
+}
+
+// wordRegexp matches the words of a text, for finding the positions of matching words
+var wordRegexp = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// snippetLength is the approximate number of bytes in a snippet
+const snippetLength = 240
+
+// snippet returns an HTML excerpt of the text around the first word that matches one of the terms,
+// with the matching words in <mark> tags
+func snippet(text string, terms []string) template.HTML {
+	isTerm := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		isTerm[term] = true
+	}
+	words := wordRegexp.FindAllStringIndex(text, -1)
+	start := 0
+	for _, w := range words {
+		if isTerm[normalizeWord(text[w[0]:w[1]])] {
+			start = w[0] - snippetLength/3
+			break
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	// Start and end at word boundaries
+	for _, w := range words {
+		if w[0] >= start {
+			start = w[0]
+			break
+		}
+	}
+	end := len(text)
+	for _, w := range words {
+		if w[1] > start+snippetLength {
+			end = w[0]
+			break
+		}
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("… ")
+	}
+	pos := start
+	for _, w := range words {
+		if w[0] < start || w[1] > end {
+			continue
+		}
+		if word := text[w[0]:w[1]]; isTerm[normalizeWord(word)] {
+			sb.WriteString(html.EscapeString(text[pos:w[0]]))
+			sb.WriteString("<mark>" + html.EscapeString(word) + "</mark>")
+			pos = w[1]
+		}
+	}
+	sb.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		sb.WriteString(" …")
+	}
+	return template.HTML(sb.String())
+}
+
+// truncate returns at most n bytes of s, without splitting UTF-8 sequences
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !isRuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// isRuneStart checks if the byte is the first byte of a UTF-8 sequence
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// defaultSearchLimit is the number of search results when no limit is given
+const defaultSearchLimit = 20
+
+// searchLimit returns the "limit" parameter, or the default limit
+func searchLimit(r *http.Request) int {
+	if limit, err := strconv.Atoi(r.FormValue("limit")); err == nil && limit > 0 {
+		return limit
+	}
+	return defaultSearchLimit
+}
+
+// searchHandler shows the search page, with the results for the "q" parameter
+func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.FormValue("q"))
+	data := PageData{Query: query}
+	if query != "" {
+		data.Results = s.Search.Search(r.Context(), query, searchLimit(r))
+	}
+	s.render(w, r, s.searchTmpl, data)
+}
+
+// searchAPIHandler returns the search results for the "q" parameter as JSON
+func (s *Server) searchAPIHandler(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.FormValue("q"))
+	results := []SearchResult{}
+	if query != "" {
+		results = s.Search.Search(r.Context(), query, searchLimit(r))
+	}
+	writeJSON(w, map[string]any{"query": query, "results": results})
+}
@@ -0,0 +1,653 @@
+package clickableai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultMainPrompt is used for generating the Markdown document for a trail of keywords
+	DefaultMainPrompt = "Generate a correct, concise, and technical Markdown document based on these keywords. No commentary: "
+	// DefaultTopicPrompt is used for generating new topics, based on the keywords and the generated document
+	DefaultTopicPrompt = "Generate exactly 10 suitable topics based on these keywords and the following content. Output as a strict comma-separated list with no commentary: "
+	// DefaultGeneralTopicPrompt is used for generating new topics if DefaultTopicPrompt gave no usable topics
+	DefaultGeneralTopicPrompt = "Generate 10 general keywords based on the following Markdown content. Output as a strict comma-separated list with no commentary: "
+	// DefaultImagePrompt is used for generating the Markdown document for an uploaded image, followed by the trail of keywords
+	DefaultImagePrompt = "Explain what this image shows to a software engineer, as a correct, concise, and technical Markdown document. If it is a diagram, explain the components and how they are connected. If it is a screenshot of an error, explain the error and how to fix it. No commentary. The keywords so far are: "
+	// imageTitlePrompt is used for getting a short description of an uploaded image, which is added to the trail
+	imageTitlePrompt = "Give a short title of at most five words for what this image shows, like \"Kubernetes cluster architecture\" or \"Go nil pointer panic\". Output only the title."
+)
+
+// Server is the clickableai web server, which serves the web page, the shared
+// assets and the endpoints for generating Markdown and topics
+type Server struct {
+	Generator          Generator
+	Cache              *PageCache
+	InitialTopics      []string
+	ExtraInHead        string
+	MainPrompt         string
+	TopicPrompt        string
+	GeneralTopicPrompt string
+	MainTemperature    float64
+	TopicTemperature   float64
+	MaxTrail           int // maximum number of keywords in a trail, 0 for no limit
+	MaxMarkdownSize    int // maximum number of bytes of Markdown used for generating topics, 0 for no limit
+	MaxImageSize       int // maximum number of bytes in an uploaded image, 0 to disable image uploads
+	ImagePrompt        string
+	DiagramPrompt      string        // describes the diagram language to the backend, or empty for no diagrams
+	PersonaModel       string        // the persona model that generates pages, which is only given the trail and the language
+	Models             *ModelManager // for managing Ollama models, nil if Ollama is not used
+	TopicRanker        *TopicRanker  // for removing near-duplicate topics and ranking them, may be nil
+	Search             *SearchIndex  // all generated pages in the cache are indexed here
+	Docs               *DocsIndex    // local documentation that is given to the backend, may be nil
+	DocsPassages       int           // the number of documentation passages per page
+	Reviewer           Generator     // reviews the claims of every generated page, may be nil
+	GoCheck            string        // one of GoCheckModes, for checking the Go code blocks of every generated page
+	EditToken          string        // the token that trusted users give for editing pages, or empty to disable editing
+	RegenerateScore    int           // a page is regenerated when the score of its canonical version falls to this, 0 to never regenerate
+	PublicURL          string        // the public base URL of the server, for link previews, or empty to take it from each request
+	ShortLinks         *ShortLinks
+	Bookmarks          *Bookmarks    // the bookmarks of every session, which are kept in Cache
+	Auth               Authenticator // finds the user of every request, or nil for no authentication
+	Metrics            *Metrics      // the requests, page views and backend usage, for the admin dashboard
+	Limiter            *Limiter      // limits the concurrent backend requests, may be nil
+	Router             *Router       // routes between several backends, may be nil
+	AnonymousRole      string        // the role of requests without a user, or empty to make them log in
+
+	tmpl          *template.Template
+	searchTmpl    *template.Template
+	historyTmpl   *template.Template
+	reportTmpl    *template.Template
+	bookmarksTmpl *template.Template
+	adminTmpl     *template.Template
+	regenerating  sync.Map // the keys of the pages that are being regenerated because of bad feedback
+}
+
+// generateResponse is the JSON response from /generate
+type generateResponse struct {
+	Markdown  string      `json:"markdown"`
+	Backend   string      `json:"backend"`
+	Lang      string      `json:"lang"`
+	Model     string      `json:"model,omitempty"`
+	Trail     []string    `json:"trail"`
+	Sources   []Passage   `json:"sources,omitempty"`
+	Diagrams  []Diagram   `json:"diagrams,omitempty"`
+	Code      []CodeBlock `json:"code,omitempty"`
+	GoChecks  []GoCheck   `json:"go_checks,omitempty"`
+	TOC       []Heading   `json:"toc,omitempty"`
+	Review    *Review     `json:"review,omitempty"`
+	Version   int         `json:"version"`              // the number of the version that is shown
+	Versions  int         `json:"versions"`             // the number of versions of the page
+	Pinned    int         `json:"pinned,omitempty"`     // the number of the pinned version, if any
+	Permalink string      `json:"permalink"`            // the canonical URL of the page
+	ShortLink string      `json:"short_link,omitempty"` // a shorter URL of the page, if there is one
+}
+
+// topicsResponse is the JSON response from /generate_topics
+type topicsResponse struct {
+	Topics []Topic `json:"topics"`
+	Lang   string  `json:"lang"`
+}
+
+// NewServer creates a new Server that uses the given Generator and the embedded assets
+func NewServer(g Generator) *Server {
+	return newServer(g, NewPageCache(0), NewMetrics(), NewSearchIndex(nil))
+}
+
+// newServer creates a new Server with the given Generator, cache, metrics and search index,
+// and with bookmarks that keep pages in the cache
+func newServer(g Generator, cache *PageCache, metrics *Metrics, search *SearchIndex) *Server {
+	s := &Server{
+		Generator:          g,
+		Cache:              cache,
+		InitialTopics:      DefaultTopics(),
+		ExtraInHead:        string(Asset("extra.conf")),
+		MainPrompt:         DefaultMainPrompt,
+		TopicPrompt:        DefaultTopicPrompt,
+		GeneralTopicPrompt: DefaultGeneralTopicPrompt,
+		ImagePrompt:        DefaultImagePrompt,
+		DiagramPrompt:      DefaultDiagramPrompt,
+		MainTemperature:    0.0,
+		TopicTemperature:   0.5,
+		Search:             search,
+		ShortLinks:         NewShortLinks(),
+		Metrics:            metrics,
+		tmpl:               template.Must(template.New("index").Parse(string(Asset("index.html")))),
+		searchTmpl:         template.Must(template.New("search").Parse(string(Asset("search.html")))),
+		historyTmpl:        template.Must(template.New("history").Parse(string(Asset("history.html")))),
+		reportTmpl:         template.Must(template.New("report").Parse(string(Asset("report.html")))),
+		bookmarksTmpl:      template.Must(template.New("bookmarks").Parse(string(Asset("bookmarks.html")))),
+		adminTmpl:          template.Must(template.New("admin").Parse(string(Asset("admin.html")))),
+	}
+	s.Cache.OnEvict = s.unindex
+	s.Bookmarks = NewBookmarks(s.Cache)
+	return s
+}
+
+// NewServerFromConfig creates a new Server with the backends, prompts and limits of the given configuration
+func NewServerFromConfig(c *Config) (*Server, error) {
+	g, err := c.NewGenerator()
+	if err != nil {
+		return nil, err
+	}
+	router := findRouter(g)
+	metrics := NewMetrics()
+	g = NewMetered(g, metrics)
+	var limiter *Limiter
+	if c.MaxConcurrent > 0 {
+		limiter = NewLimiter(g, c.MaxConcurrent)
+		g = limiter
+	}
+	var embedder Embedder
+	if c.Embeddings != "" {
+		embedder = c.NewEmbedder()
+	}
+	s := newServer(g, NewPageCache(c.CacheSize), metrics, NewSearchIndex(embedder))
+	s.Limiter = limiter
+	s.Router = router
+	if s.Reviewer, err = c.NewReviewer(g); err != nil {
+		return nil, err
+	}
+	if c.DocsDir != "" {
+		s.Docs = NewDocsIndex(embedder)
+		s.DocsPassages = c.DocsPassages
+		files, err := s.Docs.AddDir(context.Background(), c.DocsDir)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Indexed %d passages from %d files in %s\n", s.Docs.Len(), files, c.DocsDir)
+	}
+	s.InitialTopics = SplitTopics(c.InitialTopics)
+	s.MainPrompt = c.MainPrompt
+	s.TopicPrompt = c.TopicPrompt
+	s.GeneralTopicPrompt = c.GeneralTopicPrompt
+	s.MainTemperature = c.MainTemperature
+	s.TopicTemperature = c.TopicTemperature
+	s.MaxTrail = c.MaxTrail
+	s.MaxMarkdownSize = c.MaxMarkdownSize
+	s.MaxImageSize = c.MaxImageSize
+	s.ImagePrompt = c.ImagePrompt
+	s.DiagramPrompt = c.DiagramPrompt
+	s.GoCheck = c.GoCheck
+	s.EditToken = c.EditToken
+	s.RegenerateScore = c.RegenerateScore
+	s.PublicURL = c.PublicURL
+	if s.Auth, err = c.NewAuthenticator(); err != nil {
+		return nil, err
+	}
+	s.AnonymousRole = c.AuthAnonymousRole
+	if c.TopicSimilarity > 0 {
+		if embedder != nil {
+			s.TopicRanker = NewTopicRanker(embedder)
+		} else {
+			s.TopicRanker = NewTopicRanker(LexicalEmbedder{})
+		}
+		s.TopicRanker.Threshold = c.TopicSimilarity
+	}
+	if backends := SplitTopics(c.Backend); contains(backends, "ollama") {
+		oc := c.ollamaConfig()
+		s.Models = NewModelManager(oc)
+		if len(backends) == 1 {
+			s.PersonaModel = c.personaModel(oc)
+		}
+	}
+	return s, nil
+}
+
+// Handler returns a http.Handler with all the routes of the server
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/generate", s.generateHandler)
+	mux.HandleFunc("/generate_stream", s.generateStreamHandler)
+	mux.HandleFunc("/generate_topics", s.generateTopicsHandler)
+	mux.HandleFunc("/upload", s.uploadHandler)
+	mux.HandleFunc("/regenerate", s.regenerateHandler)
+	mux.HandleFunc("/edit", s.editHandler)
+	mux.HandleFunc("/pin", s.pinHandler)
+	mux.HandleFunc("/history", s.historyHandler)
+	mux.HandleFunc("/api/versions", s.versionsHandler)
+	mux.HandleFunc("/feedback", s.feedbackHandler)
+	mux.HandleFunc("/admin", s.adminHandler)
+	mux.HandleFunc("/api/admin/stats", s.adminAPIHandler)
+	mux.HandleFunc("/api/admin/purge", s.purgeHandler)
+	mux.HandleFunc("/api/admin/backend", s.defaultBackendHandler)
+	mux.HandleFunc("/admin/feedback", s.feedbackReportHandler)
+	mux.HandleFunc("/api/admin/feedback", s.feedbackReportAPIHandler)
+	mux.HandleFunc("/t/", s.trailHandler)
+	mux.HandleFunc("/p/", s.shortLinkHandler)
+	mux.HandleFunc("/bookmarks", s.bookmarksHandler)
+	mux.HandleFunc("/api/bookmarks", s.bookmarksAPIHandler)
+	mux.HandleFunc("/api/bookmarks/add", s.bookmarkActionHandler(s.addBookmark))
+	mux.HandleFunc("/api/bookmarks/remove", s.bookmarkActionHandler(s.removeBookmark))
+	mux.HandleFunc("/api/bookmarks/move", s.bookmarkActionHandler(s.moveBookmark))
+	mux.HandleFunc("/api/collections/create", s.bookmarkActionHandler(s.createCollection))
+	mux.HandleFunc("/api/collections/rename", s.bookmarkActionHandler(s.renameCollection))
+	mux.HandleFunc("/api/collections/delete", s.bookmarkActionHandler(s.deleteCollection))
+	mux.HandleFunc("/api/collections/export", s.exportCollectionHandler)
+	mux.HandleFunc("/api/me", s.meHandler)
+	mux.HandleFunc("/search", s.searchHandler)
+	mux.HandleFunc("/api/search", s.searchAPIHandler)
+	mux.HandleFunc("/api/models", s.modelsHandler)
+	mux.HandleFunc("/api/models/info", s.modelInfoHandler)
+	mux.HandleFunc("/api/models/pull", s.modelPullHandler)
+	mux.HandleFunc("/api/session/model", s.sessionModelHandler)
+	mux.HandleFunc("/githublogo.png", func(w http.ResponseWriter, r *http.Request) {
+		GithubLogoHandler(w, r, Asset("githublogo.png"))
+	})
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		RobotsHandler(w, r, string(Asset("robots.txt")))
+	})
+	mux.HandleFunc("/markdown-it.min.js", func(w http.ResponseWriter, r *http.Request) {
+		MarkdownJSHandler(w, r, Asset("markdown-it.min.js"))
+	})
+	mux.HandleFunc("/", s.indexHandler)
+	return s.countRequests(s.authenticate(mux))
+}
+
+// unindex removes an evicted page from the search index
+func (s *Server) unindex(page Page) {
+	s.Search.Remove(page.PageID)
+}
+
+// index adds the page to the search index in the background. The page is only added if it is
+// still cached with the same Markdown by then, since it may have been evicted or changed meanwhile.
+func (s *Server) index(page Page) {
+	go func() {
+		doc, vector := s.Search.prepare(context.Background(), page)
+		s.Cache.WhileCached(page.PageID, func(cached Page) {
+			if cached.Markdown == page.Markdown {
+				s.Search.insert(page.Key(), doc, vector)
+			}
+		})
+	}()
+}
+
+// ListenAndServe serves the web application on the given address
+func (s *Server) ListenAndServe(addr string) error {
+	log.Printf("Starting server on %s, using %s\n", addr, s.Generator.Name())
+	return http.ListenAndServe(addr, s.Handler())
+}
+
+// indexHandler renders the main page with the initial topics. If keywords are given, the page for
+// them is opened, like for a permalink.
+func (s *Server) indexHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	r.ParseForm()
+	if len(formKeywords(r)) > 0 {
+		s.renderPage(w, r, s.pageID(r))
+		return
+	}
+	s.render(w, r, s.tmpl, s.pageData(w, r))
+}
+
+// pageData returns the data for the main page, without a page to open. A session is started,
+// so that feedback can be given.
+func (s *Server) pageData(w http.ResponseWriter, r *http.Request) PageData {
+	s.owner(w, r, true)
+	return PageData{
+		Keywords:     NewTopics(s.InitialTopics, SourceGlossary),
+		ModelPicker:  s.Models != nil,
+		ImageUpload:  s.MaxImageSize > 0,
+		Regenerating: s.Auth == nil || UserFrom(r.Context()).Can(RoleEditor),
+		Editing:      s.editing(r),
+		EditToken:    s.Auth == nil,
+		User:         UserFrom(r.Context()),
+	}
+}
+
+// render executes the given template with the given data, localised to the language of the request
+func (s *Server) render(w http.ResponseWriter, r *http.Request, t *template.Template, data PageData) {
+	if data.Lang == "" {
+		data.Lang = NegotiateLanguage(r)
+	}
+	data.UI = UIStringsFor(data.Lang)
+	data.ExtraInHead = template.HTML(s.ExtraInHead)
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		log.Printf("Error executing template: %s\n", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// generateHandler generates (or fetches from the cache) the Markdown for a trail of keywords
+func (s *Server) generateHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	id := s.pageID(r)
+	if !s.checkTrail(w, id.Trail) || !s.checkModel(w, r) {
+		return
+	}
+	page, err := s.generatePage(r, id, nil)
+	if err != nil {
+		log.Println("Error:", err)
+		http.Error(w, "Error: Could not generate output", http.StatusBadGateway)
+		return
+	}
+	s.writePage(w, id, page)
+}
+
+// generateStreamHandler is like generateHandler, but sends the Markdown as server-sent "chunk"
+// events while it is generated, followed by a "page" event with the same JSON as /generate.
+// A cached page is sent as a "page" event right away.
+func (s *Server) generateStreamHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	id := s.pageID(r)
+	if !s.checkTrail(w, id.Trail) || !s.checkModel(w, r) {
+		return
+	}
+	sse, ok := newEventStream(w)
+	if !ok {
+		http.Error(w, "Error: Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	page, err := s.generatePage(r, id, func(chunk string) {
+		sse.send("chunk", map[string]string{"text": chunk})
+	})
+	if err != nil {
+		log.Println("Error:", err)
+		sse.send("error", map[string]string{"error": "Could not generate output"})
+		return
+	}
+	sse.send("page", s.pageResponse(id, page))
+}
+
+// generatePage returns the page from the cache, or generates and stores it.
+// If callback is not nil, it is given the Markdown chunk by chunk while it is generated.
+func (s *Server) generatePage(r *http.Request, id PageID, callback func(chunk string)) (Page, error) {
+	page, ok := s.Cache.Get(id)
+	cached := ok && page.Markdown != ""
+	s.Metrics.RecordPage(id, cached)
+	if cached {
+		return page, nil
+	}
+	resp, sources, err := s.generateMarkdown(r.Context(), id, r.FormValue("backend"), r.FormValue("section"), s.MainTemperature, callback)
+	if err != nil {
+		return Page{}, err
+	}
+	return s.storePage(r.Context(), id, Version{
+		Kind:        VersionGenerated,
+		Markdown:    resp.Text,
+		Backend:     resp.Backend,
+		Temperature: s.MainTemperature,
+		Sources:     sources,
+	}), nil
+}
+
+// storePage checks the Go code blocks of a new version of a page if enabled, reviews it if there is a
+// reviewer, stores it in the cache and adds the page to the search index. Returns the page, showing
+// the new version, even if another version is pinned.
+func (s *Server) storePage(ctx context.Context, id PageID, v Version) Page {
+	if v.Kind == VersionEdited {
+		// What trusted users wrote is checked, but never repaired
+		if s.GoCheck == "check" || s.GoCheck == "repair" {
+			v.GoChecks = CheckGoBlocks(v.Markdown)
+		}
+	} else {
+		v.Markdown, v.GoChecks = s.checkGo(ctx, id, v.Markdown)
+	}
+	if s.Reviewer != nil {
+		review, err := ReviewMarkdown(ctx, s.Reviewer, v.Markdown, v.Sources)
+		if err != nil {
+			log.Println("Error:", err)
+		} else {
+			v.Review = &review
+		}
+	}
+	if v.Kind != VersionEdited {
+		v.Prompt = s.promptVersion()
+	}
+	v = s.Cache.AddVersion(id, v)
+	page, _ := s.Cache.Get(id)
+	s.index(page)
+	return page.WithVersion(v)
+}
+
+// promptVersion returns a short hash of the prompts and the persona model that pages are generated
+// with, so that feedback can be compared between prompt changes. The tag of the persona model is a
+// hash of its Modelfile, which has the prompts it is given.
+func (s *Server) promptVersion() string {
+	h := fnv.New32a()
+	for _, prompt := range []string{s.MainPrompt, s.DiagramPrompt, s.ImagePrompt, s.PersonaModel} {
+		h.Write([]byte(prompt + "\x00"))
+	}
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// writePage writes the given page as the JSON response from /generate
+func (s *Server) writePage(w http.ResponseWriter, id PageID, page Page) {
+	w.Header().Set("X-Backend", page.Backend)
+	writeJSON(w, s.pageResponse(id, page))
+}
+
+// pageResponse returns the given page as the JSON response from /generate
+func (s *Server) pageResponse(id PageID, page Page) generateResponse {
+	return generateResponse{
+		Markdown:  page.Markdown,
+		Backend:   page.Backend,
+		Lang:      id.Lang,
+		Model:     id.Model,
+		Trail:     id.Trail,
+		Sources:   page.Sources,
+		Diagrams:  RenderDiagrams(page.Markdown),
+		Code:      HighlightCodeBlocks(page.Markdown),
+		TOC:       TableOfContents(page.Markdown),
+		GoChecks:  page.GoChecks,
+		Review:    page.Review,
+		Version:   page.Version,
+		Versions:  len(page.Versions),
+		Pinned:    page.Pinned,
+		Permalink: Permalink(id),
+		ShortLink: s.ShortLinks.Add(id),
+	}
+}
+
+// generateTopicsHandler generates (or fetches from the cache) new topics for a trail of keywords
+func (s *Server) generateTopicsHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	id := s.pageID(r)
+	if !s.checkTrail(w, id.Trail) || !s.checkModel(w, r) {
+		return
+	}
+
+	page, ok := s.Cache.Get(id)
+	if ok && len(page.Topics) > 0 {
+		writeJSON(w, topicsResponse{Topics: page.Topics, Lang: id.Lang})
+		return
+	}
+
+	markdown := page.Markdown
+	if markdown == "" {
+		markdown = r.FormValue("markdown")
+	}
+	if s.MaxMarkdownSize > 0 {
+		markdown = truncate(markdown, s.MaxMarkdownSize)
+	}
+
+	topics, err := s.generateTopics(r.Context(), id, markdown)
+	if err != nil {
+		log.Println("Error:", err)
+		http.Error(w, "Error: Could not generate topics", http.StatusBadGateway)
+		return
+	}
+	if s.TopicRanker != nil {
+		if topics, err = s.TopicRanker.Rank(r.Context(), topics, id.Trail, markdown); err != nil {
+			log.Println("Error:", err)
+		}
+	}
+	s.Cache.SetTopics(id, topics)
+
+	writeJSON(w, topicsResponse{Topics: topics, Lang: id.Lang})
+}
+
+// generateMarkdown generates a Markdown document for the page with the given ID.
+// If there is local documentation, the best matching passages are given to the backend,
+// listed as sources at the end of the document and returned. If a section ID is given, that
+// section of the previous page in the trail is also given to the backend.
+// If callback is not nil, it is given the text chunk by chunk while it is generated.
+func (s *Server) generateMarkdown(ctx context.Context, id PageID, backend, section string, temperature float64, callback func(chunk string)) (Response, []Passage, error) {
+	var (
+		prompt  = s.sectionPrompt(id, section)
+		sources []Passage
+	)
+	if s.Docs != nil && s.DocsPassages > 0 && len(id.Trail) > 0 {
+		sources = s.Docs.Retrieve(ctx, strings.Join(id.Trail, " "), s.DocsPassages)
+		if len(sources) > 0 {
+			prompt = docsPrompt(sources) + prompt
+		}
+	}
+	model := id.Model
+	if model == "" && s.PersonaModel != "" {
+		// The persona model has the instructions in its system prompt
+		model = s.PersonaModel
+	} else {
+		prompt += s.DiagramPrompt + s.MainPrompt
+	}
+	prompt += strings.Join(id.Trail, " -> ") + LanguageInstruction(id.Lang)
+	req := Request{
+		Prompt:      prompt,
+		Temperature: temperature,
+		Model:       model,
+		Backend:     backend,
+	}
+	var (
+		resp Response
+		err  error
+	)
+	if callback != nil {
+		resp, err = GenerateResponseStream(ctx, s.Generator, req, callback)
+	} else {
+		resp, err = GenerateResponse(ctx, s.Generator, req)
+	}
+	if err != nil {
+		return Response{}, nil, err
+	}
+	if len(sources) > 0 {
+		resp.Text += sourcesMarkdown(UIStringsFor(id.Lang).Sources, sources)
+	}
+	return resp, sources, nil
+}
+
+// sectionPrompt returns the start of a prompt that gives the section with the given ID, of the
+// previous page in the trail, as context. Returns an empty string if there is no such section.
+func (s *Server) sectionPrompt(id PageID, section string) string {
+	if section == "" || len(id.Trail) < 2 {
+		return ""
+	}
+	parent := id
+	parent.Trail = id.Trail[:len(id.Trail)-1]
+	page, ok := s.Cache.Get(parent)
+	if !ok {
+		return ""
+	}
+	heading, text, ok := Section(page.Markdown, section)
+	if !ok {
+		return ""
+	}
+	if s.MaxMarkdownSize > 0 {
+		text = truncate(text, s.MaxMarkdownSize)
+	}
+	return "This page goes deeper into the section \"" + heading.Text + "\" of the page about " + strings.Join(parent.Trail, " -> ") + ". The section is:\n\n" + text + "\n\n"
+}
+
+// generateTopics generates new topics for the given trail and Markdown document.
+// If no usable topics are found, general topics based on only the Markdown are generated instead.
+func (s *Server) generateTopics(ctx context.Context, id PageID, markdown string) ([]Topic, error) {
+	prompt := s.TopicPrompt + strings.Join(id.Trail, ", ") + " | Content: " + markdown + LanguageInstruction(id.Lang)
+	topics, err := GenerateTopics(ctx, s.Generator, prompt, s.TopicTemperature, id.Trail)
+	if err == nil && !isErrorTopics(topics) {
+		return topics, nil
+	}
+	if err != nil {
+		log.Println("Error:", err)
+	}
+
+	log.Printf("Generating general new topics for %d bytes of Markdown.\n", len(markdown))
+
+	prompt = s.GeneralTopicPrompt + markdown + LanguageInstruction(id.Lang)
+	return GenerateTopics(ctx, s.Generator, prompt, s.TopicTemperature, []string{})
+}
+
+// pageID returns the ID of the page that is requested, from the keywords in the parsed form,
+// the negotiated language and the model selected for the session
+func (s *Server) pageID(r *http.Request) PageID {
+	return PageID{
+		Lang:  NegotiateLanguage(r),
+		Model: s.sessionModel(r),
+		Trail: formKeywords(r),
+	}
+}
+
+// checkTrail responds with an error and returns false if the trail is too long
+func (s *Server) checkTrail(w http.ResponseWriter, trail []string) bool {
+	if s.MaxTrail > 0 && len(trail) > s.MaxTrail {
+		http.Error(w, fmt.Sprintf("Error: Too many keywords (the maximum is %d)", s.MaxTrail), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// isErrorTopics checks if the topics are the error placeholder returned by ExtractAndShortenTopics
+func isErrorTopics(topics []Topic) bool {
+	return len(topics) == 0 || (len(topics) == 1 && strings.HasPrefix(topics[0].Name, "Error"))
+}
+
+// formKeywords returns the keywords from a parsed form.
+// Both repeated "keywords" fields and a single comma-separated field are supported.
+func formKeywords(r *http.Request) []string {
+	var keywords []string
+	for _, value := range r.Form["keywords"] {
+		keywords = append(keywords, SplitTopics(value)...)
+	}
+	return keywords
+}
+
+// writeJSON writes the given value as a JSON response
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println("Error:", err)
+	}
+}
+
+// eventStream writes server-sent events
+type eventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// newEventStream sets the headers for server-sent events. Returns false if streaming is not supported.
+func newEventStream(w http.ResponseWriter) (*eventStream, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	return &eventStream{w: w, flusher: flusher}, true
+}
+
+// send writes an event with the given value as JSON data, and flushes it to the client
+func (es *eventStream) send(event string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Println("Error:", err)
+		return
+	}
+	fmt.Fprintf(es.w, "event: %s\ndata: %s\n\n", event, data)
+	es.flusher.Flush()
+}
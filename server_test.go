@@ -0,0 +1,346 @@
+package clickableai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// newTestServer starts the web application with the fake backend
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(NewServer(NewFake(1)).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// postJSON posts a form and decodes the JSON response
+func postJSON(t *testing.T, server *httptest.Server, path string, form url.Values, v any) {
+	t.Helper()
+	resp, err := http.PostForm(server.URL+path, form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("%s: %s: %s", path, resp.Status, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateHandler(t *testing.T) {
+	server := newTestServer(t)
+	form := url.Values{"keywords": {"Go,Concurrency"}, "lang": {"en"}}
+	var first, second generateResponse
+	postJSON(t, server, "/generate", form, &first)
+	if !strings.Contains(first.Markdown, "Concurrency") {
+		t.Errorf("the page is not about the last keyword:\n%s", first.Markdown)
+	}
+	if first.Backend != "fake" || first.Lang != "en" || strings.Join(first.Trail, ",") != "Go,Concurrency" {
+		t.Errorf("unexpected response: %+v", first)
+	}
+	if first.Permalink != "/t/Go/Concurrency?lang=en" {
+		t.Errorf("Permalink = %q", first.Permalink)
+	}
+	postJSON(t, server, "/generate", form, &second)
+	if second.Markdown != first.Markdown || second.Versions != 1 {
+		t.Errorf("the page was not served from the cache: %+v", second)
+	}
+}
+
+// postEvents posts a form and returns the server-sent events of the response, in order
+func postEvents(t *testing.T, server *httptest.Server, path string, form url.Values) (events []string, data []string) {
+	t.Helper()
+	resp, err := http.PostForm(server.URL+path, form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("%s: %s: %s", path, resp.Status, body)
+	}
+	for _, message := range strings.Split(strings.TrimSpace(string(body)), "\n\n") {
+		event, value, _ := strings.Cut(message, "\n")
+		events = append(events, strings.TrimPrefix(event, "event: "))
+		data = append(data, strings.TrimPrefix(value, "data: "))
+	}
+	return events, data
+}
+
+func TestGenerateStreamHandler(t *testing.T) {
+	// The fake backend streams word by word, also through the wrappers that the configuration adds
+	g := NewLimiter(NewMetered(NewRouter(NewFake(1)), NewMetrics()), 1)
+	server := httptest.NewServer(NewServer(g).Handler())
+	defer server.Close()
+	form := url.Values{"keywords": {"Go,Concurrency"}, "lang": {"en"}}
+
+	events, data := postEvents(t, server, "/generate_stream", form)
+	if len(events) < 3 || events[len(events)-1] != "page" {
+		t.Fatalf("expected several chunks and then the page, got %q", events)
+	}
+	var streamed strings.Builder
+	for i, event := range events[:len(events)-1] {
+		var chunk struct {
+			Text string `json:"text"`
+		}
+		if event != "chunk" || json.Unmarshal([]byte(data[i]), &chunk) != nil {
+			t.Fatalf("unexpected event %q: %s", event, data[i])
+		}
+		streamed.WriteString(chunk.Text)
+	}
+	var page generateResponse
+	if err := json.Unmarshal([]byte(data[len(data)-1]), &page); err != nil {
+		t.Fatal(err)
+	}
+	if page.Markdown != strings.TrimSpace(streamed.String()) || page.Backend != "fake" || page.Permalink != "/t/Go/Concurrency?lang=en" {
+		t.Errorf("the page does not match the streamed text: %+v", page)
+	}
+
+	// A cached page is sent right away
+	events, _ = postEvents(t, server, "/generate_stream", form)
+	if len(events) != 1 || events[0] != "page" {
+		t.Errorf("expected only the cached page, got %q", events)
+	}
+}
+
+func TestGenerateHandlerTrailLimit(t *testing.T) {
+	s := NewServer(NewFake(1))
+	s.MaxTrail = 20
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+	resp, err := http.PostForm(server.URL+"/generate", url.Values{"keywords": {strings.Repeat("Go,", 30)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("a too long trail gave %s", resp.Status)
+	}
+}
+
+// promptCapture is a Generator that remembers the prompts and models it is given
+type promptCapture struct {
+	prompts []string
+	models  []string
+}
+
+func (pc *promptCapture) Name() string {
+	return "capture"
+}
+
+func (pc *promptCapture) Generate(ctx context.Context, req Request) (string, error) {
+	pc.prompts = append(pc.prompts, req.Prompt)
+	pc.models = append(pc.models, req.Model)
+	return "# Page", nil
+}
+
+func TestGenerateMarkdownPersona(t *testing.T) {
+	capture := &promptCapture{}
+	s := NewServer(capture)
+	s.PersonaModel = "clickableai-persona:0123456789ab"
+	id := PageID{Lang: "en", Trail: []string{"Go", "Channels"}}
+	if _, _, err := s.generateMarkdown(context.Background(), id, "", "", 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Go -> Channels" + LanguageInstruction("en"); capture.prompts[0] != want {
+		t.Errorf("the persona model was given %q, want %q", capture.prompts[0], want)
+	}
+	if capture.models[0] != s.PersonaModel {
+		t.Errorf("the page was generated with %q, not the persona model", capture.models[0])
+	}
+	id.Model = "llama3.2"
+	if _, _, err := s.generateMarkdown(context.Background(), id, "", "", 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(capture.prompts[1], s.DiagramPrompt+s.MainPrompt) || capture.models[1] != id.Model {
+		t.Errorf("another model was not given the main prompt: %q", capture.prompts[1])
+	}
+	// The persona model only generates pages
+	if _, err := s.generateTopics(context.Background(), id, "# Page"); err != nil {
+		t.Fatal(err)
+	}
+	if capture.models[2] != "" {
+		t.Errorf("the topics were generated with %q, not the default model", capture.models[2])
+	}
+}
+
+func TestGenerateTopicsHandler(t *testing.T) {
+	server := newTestServer(t)
+	form := url.Values{"keywords": {"Go"}, "lang": {"en"}}
+	var page generateResponse
+	postJSON(t, server, "/generate", form, &page)
+	var first, second topicsResponse
+	postJSON(t, server, "/generate_topics", form, &first)
+	if len(first.Topics) == 0 || first.Lang != "en" {
+		t.Fatalf("unexpected response: %+v", first)
+	}
+	for _, topic := range first.Topics {
+		if topic.Name == "" {
+			t.Errorf("a topic has no name: %+v", topic)
+		}
+	}
+	postJSON(t, server, "/generate_topics", form, &second)
+	if len(second.Topics) != len(first.Topics) || second.Topics[0].Name != first.Topics[0].Name {
+		t.Errorf("the topics were not served from the cache: %+v", second.Topics)
+	}
+}
+
+func TestGenerateTopicsHandlerTruncatesByRune(t *testing.T) {
+	capture := &promptCapture{}
+	s := NewServer(capture)
+	s.MaxMarkdownSize = 2
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+	resp, err := http.PostForm(server.URL+"/generate_topics", url.Values{"keywords": {"Go"}, "lang": {"en"}, "markdown": {"Gö is fun"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(capture.prompts) == 0 || !utf8.ValidString(capture.prompts[0]) || !strings.Contains(capture.prompts[0], "Content: G"+LanguageInstruction("en")) {
+		t.Errorf("the Markdown was not truncated at a rune boundary: %q", capture.prompts)
+	}
+}
+
+func TestSearchHandler(t *testing.T) {
+	server := newTestServer(t)
+	var page generateResponse
+	postJSON(t, server, "/generate", url.Values{"keywords": {"Go,Channels"}, "lang": {"en"}}, &page)
+
+	var found struct {
+		Query   string         `json:"query"`
+		Results []SearchResult `json:"results"`
+	}
+	// Pages may be indexed in the background
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		postJSON(t, server, "/api/search", url.Values{"q": {"channels"}}, &found)
+		if len(found.Results) > 0 {
+			break
+		}
+	}
+	if len(found.Results) == 0 {
+		t.Fatal("the generated page was not found")
+	}
+	if result := found.Results[0]; strings.Join(result.Trail, ",") != "Go,Channels" || result.URL != page.Permalink {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	resp, err := http.Get(server.URL + "/search?q=channels&lang=en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), page.Permalink) {
+		t.Errorf("the search page did not link to the result: %s", resp.Status)
+	}
+
+	postJSON(t, server, "/api/search", url.Values{"q": {"zebra quartz"}}, &found)
+	if len(found.Results) != 0 {
+		t.Errorf("an unrelated query found %+v", found.Results)
+	}
+}
+
+func TestEvictedPagesAreNotIndexed(t *testing.T) {
+	s := NewServer(NewFake(1))
+	s.Cache = NewPageCache(1)
+	s.Cache.OnEvict = s.unindex
+	first := s.storePage(context.Background(), PageID{Lang: "en", Trail: []string{"Go"}}, Version{Kind: VersionGenerated, Markdown: "# Go\n\nGoroutines"})
+	s.storePage(context.Background(), PageID{Lang: "en", Trail: []string{"Rust"}}, Version{Kind: VersionGenerated, Markdown: "# Rust\n\nOwnership"})
+	// The first page was evicted before it could be indexed in the background
+	time.Sleep(100 * time.Millisecond)
+	if _, ok := s.Cache.Get(first.PageID); ok {
+		t.Fatal("the first page was not evicted")
+	}
+	if results := s.Search.Search(context.Background(), "goroutines", 10); len(results) > 0 {
+		t.Errorf("the evicted page was indexed: %+v", results)
+	}
+}
+
+func TestFeedbackOncePerSession(t *testing.T) {
+	server := newTestServer(t)
+	form := url.Values{"keywords": {"Go"}, "lang": {"en"}}
+	var page generateResponse
+	postJSON(t, server, "/generate", form, &page)
+
+	feedback := url.Values{"keywords": {"Go"}, "lang": {"en"}, "rating": {"down"}}
+	resp, err := http.PostForm(server.URL+"/feedback", feedback)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("feedback without a session gave %s", resp.Status)
+	}
+
+	// The main page starts a session
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Jar: jar}
+	resp, err = client.Get(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	var score FeedbackScore
+	for range 2 {
+		resp, err = client.PostForm(server.URL+"/feedback", feedback)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&score); err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if score.Score != -1 || score.Down != 1 {
+		t.Errorf("two ratings from one session gave %+v", score)
+	}
+	feedback.Set("rating", "up")
+	resp, err = client.PostForm(server.URL+"/feedback", feedback)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&score); err != nil {
+		t.Fatal(err)
+	}
+	if score.Score != 1 || score.Up != 1 || score.Down != 0 {
+		t.Errorf("a changed rating gave %+v", score)
+	}
+}
+
+func TestNewServerFromConfig(t *testing.T) {
+	c := DefaultConfig()
+	c.Backend = "fake"
+	c.CacheSize = 5
+	s, err := NewServerFromConfig(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Cache.MaxPages != 5 || s.Bookmarks.Cache != s.Cache {
+		t.Errorf("the bookmarks do not use the configured cache")
+	}
+	if _, err := s.Generator.Generate(context.Background(), Request{Prompt: "Explain this: Go"}); err != nil {
+		t.Fatal(err)
+	}
+	if backends := s.Metrics.Snapshot(10).Backends; len(backends) != 1 {
+		t.Errorf("the backend request was not recorded in the metrics of the server: %+v", backends)
+	}
+}
@@ -0,0 +1,135 @@
+package clickableai
+
+import (
+	"math"
+	"sort"
+)
+
+// termIndex is an inverted index that scores documents with BM25.
+// It is not concurrency-safe, so the caller must lock it.
+type termIndex struct {
+	lengths  map[string]int            // document key -> number of terms
+	total    int                       // the total number of terms
+	postings map[string]map[string]int // term -> document key -> term frequency
+	terms    map[string][]string       // document key -> the distinct terms of the document
+}
+
+// newTermIndex creates a new and empty termIndex
+func newTermIndex() *termIndex {
+	return &termIndex{
+		lengths:  make(map[string]int),
+		postings: make(map[string]map[string]int),
+		terms:    make(map[string][]string),
+	}
+}
+
+// weightedTerms counts the terms of a title and a text, where the words of the title count more
+func weightedTerms(title, text string, titleWeight int) map[string]int {
+	terms := make(map[string]int)
+	for _, term := range searchTerms(title) {
+		terms[term] += titleWeight
+	}
+	for _, term := range searchTerms(text) {
+		terms[term]++
+	}
+	return terms
+}
+
+// add indexes a document, given its term frequencies, replacing the document with the same key
+func (ti *termIndex) add(key string, terms map[string]int) {
+	ti.remove(key)
+	length := 0
+	distinct := make([]string, 0, len(terms))
+	for term, tf := range terms {
+		if ti.postings[term] == nil {
+			ti.postings[term] = make(map[string]int)
+		}
+		ti.postings[term][key] = tf
+		length += tf
+		distinct = append(distinct, term)
+	}
+	ti.lengths[key] = length
+	ti.terms[key] = distinct
+	ti.total += length
+}
+
+// remove removes the document with the given key, from the postings of its own terms only
+func (ti *termIndex) remove(key string) {
+	length, ok := ti.lengths[key]
+	if !ok {
+		return
+	}
+	delete(ti.lengths, key)
+	ti.total -= length
+	for _, term := range ti.terms[key] {
+		docs := ti.postings[term]
+		delete(docs, key)
+		if len(docs) == 0 {
+			delete(ti.postings, term)
+		}
+	}
+	delete(ti.terms, key)
+}
+
+// scores returns the BM25 scores of the documents that contain at least one of the terms,
+// divided by the highest score, so that the best document has a score of 1
+func (ti *termIndex) scores(terms []string) map[string]float64 {
+	const k1, b = 1.2, 0.75
+	n := float64(len(ti.lengths))
+	avgLength := float64(ti.total) / math.Max(n, 1)
+	scores := make(map[string]float64)
+	maxScore := 0.0
+	for _, term := range terms {
+		docs := ti.postings[term]
+		idf := math.Log(1 + (n-float64(len(docs))+0.5)/(float64(len(docs))+0.5))
+		for key, tf := range docs {
+			f := float64(tf)
+			scores[key] += idf * f * (k1 + 1) / (f + k1*(1-b+b*float64(ti.lengths[key])/avgLength))
+			maxScore = math.Max(maxScore, scores[key])
+		}
+	}
+	for key := range scores {
+		scores[key] /= maxScore
+	}
+	return scores
+}
+
+// minSimilarity is the similarity of meaning that a document without matching words must have to be found
+const minSimilarity = 0.5
+
+// hybridScores combines the lexical scores with the similarity between the query vector and the
+// document vectors, where both count as much. If there is no query vector, the lexical scores are returned.
+func hybridScores(lexical map[string]float64, queryVector []float64, vectors map[string][]float64) map[string]float64 {
+	if queryVector == nil {
+		return lexical
+	}
+	scores := make(map[string]float64, len(lexical))
+	for key, score := range lexical {
+		scores[key] = score / 2
+	}
+	for key, vector := range vectors {
+		similarity := CosineSimilarity(queryVector, vector)
+		if _, ok := lexical[key]; ok || similarity >= minSimilarity {
+			scores[key] += math.Max(similarity, 0) / 2
+		}
+	}
+	return scores
+}
+
+// rankedKeys returns up to limit keys, sorted by descending score (0 for no limit)
+func rankedKeys(scores map[string]float64, limit int) []string {
+	keys := make([]string, 0, len(scores))
+	for key := range scores {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if scores[keys[i]] != scores[keys[j]] {
+			return scores[keys[i]] > scores[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
@@ -0,0 +1,21 @@
+package clickableai
+
+import "testing"
+
+func TestTermIndexRemove(t *testing.T) {
+	ti := newTermIndex()
+	ti.add("a", map[string]int{"go": 2, "channels": 1})
+	ti.add("b", map[string]int{"go": 1, "maps": 1})
+	ti.add("a", map[string]int{"goroutines": 1})
+	if _, ok := ti.postings["channels"]; ok {
+		t.Error("the replaced document is still in the postings")
+	}
+	if scores := ti.scores([]string{"go"}); len(scores) != 1 || scores["b"] != 1 {
+		t.Errorf("scores = %v", scores)
+	}
+	ti.remove("a")
+	ti.remove("b")
+	if len(ti.postings) != 0 || len(ti.terms) != 0 || len(ti.lengths) != 0 || ti.total != 0 {
+		t.Errorf("the index is not empty after removing every document: %+v", ti)
+	}
+}
@@ -0,0 +1,131 @@
+package clickableai
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Heading is a heading in a Markdown document, as an entry in the table of contents
+type Heading struct {
+	Level int    `json:"level"` // from 1 to 6
+	Text  string `json:"text"`  // without Markdown formatting
+	ID    string `json:"id"`    // a slug that is unique within the document, for linking to the section
+}
+
+var (
+	// atxHeadingRegexp matches a Markdown heading, and an optional closing sequence of #
+	atxHeadingRegexp = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)(\s+#+)?\s*$`)
+	// inlineFormattingRegexp matches the emphasis and code markers of Markdown
+	inlineFormattingRegexp = regexp.MustCompile("\\*\\*|__|[*`]")
+)
+
+// headingLine is a heading, together with the index of its line in the document
+type headingLine struct {
+	Heading
+	line int
+}
+
+// headingLines returns the headings of a Markdown document, outside of code blocks,
+// with slugs that are made unique by appending -1, -2 and so on
+func headingLines(lines []string) []headingLine {
+	var (
+		headings []headingLine
+		fence    string
+	)
+	used := make(map[string]bool)
+	for i, line := range lines {
+		if fence != "" {
+			if isClosingFence(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if m := fenceRegexp.FindStringSubmatch(line); m != nil {
+			fence = m[1]
+			continue
+		}
+		m := atxHeadingRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		text := headingText(m[2])
+		if text == "" {
+			continue
+		}
+		slug := Slug(text)
+		id := slug
+		for n := 1; used[id]; n++ {
+			id = slug + "-" + strconv.Itoa(n)
+		}
+		used[id] = true
+		headings = append(headings, headingLine{Heading: Heading{Level: len(m[1]), Text: text, ID: id}, line: i})
+	}
+	return headings
+}
+
+// headingText removes links, emphasis and code markers from the text of a heading
+func headingText(text string) string {
+	text = markdownLinkRegexp.ReplaceAllString(text, "$1")
+	text = inlineFormattingRegexp.ReplaceAllString(text, "")
+	return strings.TrimSpace(whitespaceRegexp.ReplaceAllString(text, " "))
+}
+
+// Slug turns the text of a heading into an ID, of lowercase letters, digits and dashes
+func Slug(text string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+		case unicode.IsSpace(r) || r == '-' || r == '_' || r == '.' || r == '/':
+			dash = true
+		}
+	}
+	if sb.Len() == 0 {
+		return "section"
+	}
+	return sb.String()
+}
+
+// splitLines splits a Markdown document into lines
+func splitLines(markdown string) []string {
+	return strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+}
+
+// TableOfContents returns the headings of a Markdown document, in the order they appear
+func TableOfContents(markdown string) []Heading {
+	lines := headingLines(splitLines(markdown))
+	toc := make([]Heading, len(lines))
+	for i, h := range lines {
+		toc[i] = h.Heading
+	}
+	return toc
+}
+
+// Section returns the heading with the given ID, and the Markdown of its section, which lasts until
+// the next heading on the same or a higher level. Returns false if there is no such heading.
+func Section(markdown, id string) (Heading, string, bool) {
+	lines := splitLines(markdown)
+	headings := headingLines(lines)
+	for i, h := range headings {
+		if h.ID != id {
+			continue
+		}
+		end := len(lines)
+		for _, next := range headings[i+1:] {
+			if next.Level <= h.Level {
+				end = next.line
+				break
+			}
+		}
+		return h.Heading, strings.TrimSpace(strings.Join(lines[h.line:end], "\n")), true
+	}
+	return Heading{}, "", false
+}
@@ -0,0 +1,138 @@
+package clickableai
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Relation is how a suggested topic relates to the current page
+type Relation string
+
+// The relations that a topic can have to the current page
+const (
+	RelationPrerequisite Relation = "prerequisite" // should be understood before the current page
+	RelationDeeper       Relation = "deeper"       // goes into more detail than the current page
+	RelationRelated      Relation = "related"      // is on the same level as the current page
+	RelationAlternative  Relation = "alternative"  // can be used instead of the subject of the current page
+)
+
+// Relations are the known relations, in the order they are shown in the sidebar
+var Relations = []Relation{RelationPrerequisite, RelationDeeper, RelationRelated, RelationAlternative}
+
+// TopicSource is where a topic comes from
+type TopicSource string
+
+// The sources that a topic can come from
+const (
+	SourceModel    TopicSource = "model"    // suggested by a backend
+	SourceGlossary TopicSource = "glossary" // from the list of initial topics
+	SourceUser     TopicSource = "user"     // selected by the user, from the generated text
+)
+
+// defaultConfidence is the confidence of topics that were not given a confidence by the backend
+const defaultConfidence = 0.5
+
+// Topic is a topic that can be added to the trail, together with information about why it is suggested
+type Topic struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"` // one line about the topic
+	Relation    Relation    `json:"relation,omitempty"`
+	Difficulty  string      `json:"difficulty,omitempty"` // beginner, intermediate or advanced
+	Confidence  float64     `json:"confidence"`           // from 0 to 1
+	Source      TopicSource `json:"source"`
+}
+
+// UnmarshalJSON accepts both a plain string and an object. "reason" is accepted as an alias for "description".
+func (t *Topic) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = Topic{Name: name}
+		return nil
+	}
+	type topic Topic // without the UnmarshalJSON method
+	var obj struct {
+		topic
+		Reason     string   `json:"reason"`
+		Confidence *float64 `json:"confidence"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*t = Topic(obj.topic)
+	if t.Description == "" {
+		t.Description = obj.Reason
+	}
+	if obj.Confidence != nil {
+		t.Confidence = *obj.Confidence
+	}
+	return nil
+}
+
+// normalize trims the fields and replaces unknown or missing values with defaults
+func (t *Topic) normalize(source TopicSource) {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Description = strings.TrimSpace(t.Description)
+	t.Relation = Relation(strings.ToLower(strings.TrimSpace(string(t.Relation))))
+	if !containsRelation(Relations, t.Relation) {
+		t.Relation = RelationRelated
+	}
+	t.Difficulty = strings.ToLower(strings.TrimSpace(t.Difficulty))
+	if t.Confidence <= 0 || t.Confidence > 1 {
+		t.Confidence = defaultConfidence
+	}
+	if t.Source == "" {
+		t.Source = source
+	}
+}
+
+// NewTopics creates topics from plain names, with the given source
+func NewTopics(names []string, source TopicSource) []Topic {
+	topics := make([]Topic, 0, len(names))
+	for _, name := range names {
+		topics = append(topics, Topic{Name: name, Confidence: defaultConfidence, Source: source})
+	}
+	return topics
+}
+
+// TopicNames returns the names of the given topics
+func TopicNames(topics []Topic) []string {
+	names := make([]string, len(topics))
+	for i, topic := range topics {
+		names[i] = topic.Name
+	}
+	return names
+}
+
+// TopicGroup is a group of topics with the same relation to the current page
+type TopicGroup struct {
+	Relation Relation
+	Topics   []Topic
+}
+
+// GroupTopics groups the topics by relation, in the order of Relations.
+// Topics without a relation are placed in a group of their own, first.
+func GroupTopics(topics []Topic) []TopicGroup {
+	var groups []TopicGroup
+	for _, relation := range append([]Relation{""}, Relations...) {
+		var group []Topic
+		for _, topic := range topics {
+			if topic.Relation == relation {
+				group = append(group, topic)
+			}
+		}
+		if len(group) > 0 {
+			groups = append(groups, TopicGroup{Relation: relation, Topics: group})
+		}
+	}
+	return groups
+}
+
+// containsRelation checks if the given relation is in the slice
+func containsRelation(relations []Relation, relation Relation) bool {
+	for _, r := range relations {
+		if r == relation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package clickableai
+
+import (
+	"context"
+	"sort"
+)
+
+// TopicRanker removes near-duplicate topics, and topics that are too similar to the trail,
+// and ranks the rest by relevance to the current page and by diversity
+type TopicRanker struct {
+	Embedder  Embedder
+	Threshold float64 // topics that are more similar than this are duplicates, from 0 to 1
+	Diversity float64 // how much diversity counts, compared to relevance, from 0 to 1
+	MaxPage   int     // the maximum number of bytes of the page that is embedded
+}
+
+// NewTopicRanker creates a new TopicRanker with the given Embedder and default settings
+func NewTopicRanker(e Embedder) *TopicRanker {
+	return &TopicRanker{Embedder: e, Threshold: 0.8, Diversity: 0.3, MaxPage: 2000}
+}
+
+// Rank returns the topics without near-duplicates and without topics that are too similar to
+// the trail, with the most relevant and diverse topics first. If there are topics, at least one
+// is returned. If the topics can not be embedded, they are returned as they are, together with the error.
+func (tr *TopicRanker) Rank(ctx context.Context, topics []Topic, trail []string, page string) ([]Topic, error) {
+	if len(topics) < 2 && len(trail) == 0 {
+		return topics, nil
+	}
+	if tr.MaxPage > 0 {
+		page = truncate(page, tr.MaxPage)
+	}
+
+	// Embed the topics, the trail and the page in one request
+	texts := append(TopicNames(topics), trail...)
+	texts = append(texts, page)
+	vectors, err := tr.Embedder.Embed(ctx, texts)
+	if err != nil {
+		return topics, err
+	}
+	topicVectors, trailVectors, pageVector := vectors[:len(topics)], vectors[len(topics):len(topics)+len(trail)], vectors[len(vectors)-1]
+
+	// The most confident topic of a cluster of near-duplicates is kept
+	order := make([]int, len(topics))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return topics[order[a]].Confidence > topics[order[b]].Confidence
+	})
+	var candidates []int
+	for _, i := range order {
+		if tr.similarToAny(topicVectors[i], trailVectors) {
+			continue
+		}
+		duplicate := false
+		for _, j := range candidates {
+			if CosineSimilarity(topicVectors[i], topicVectors[j]) >= tr.Threshold {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 && len(order) > 0 {
+		// At least the most confident topic is kept, so that there is always a topic to follow
+		candidates = append(candidates, order[0])
+	}
+
+	// Maximal marginal relevance: pick the topic that is most relevant to the page,
+	// and least similar to the topics that are already picked
+	relevance := make(map[int]float64, len(candidates))
+	for _, i := range candidates {
+		relevance[i] = (CosineSimilarity(topicVectors[i], pageVector) + topics[i].Confidence) / 2
+	}
+	ranked := make([]Topic, 0, len(candidates))
+	var picked []int
+	for len(candidates) > 0 {
+		best, bestScore := 0, 0.0
+		for n, i := range candidates {
+			maxSimilarity := 0.0
+			for _, j := range picked {
+				if sim := CosineSimilarity(topicVectors[i], topicVectors[j]); sim > maxSimilarity {
+					maxSimilarity = sim
+				}
+			}
+			score := (1-tr.Diversity)*relevance[i] - tr.Diversity*maxSimilarity
+			if n == 0 || score > bestScore {
+				best, bestScore = n, score
+			}
+		}
+		i := candidates[best]
+		ranked = append(ranked, topics[i])
+		picked = append(picked, i)
+		candidates = append(candidates[:best], candidates[best+1:]...)
+	}
+	return ranked, nil
+}
+
+// similarToAny checks if the vector is at least as similar as the threshold to one of the other vectors
+func (tr *TopicRanker) similarToAny(v []float64, others [][]float64) bool {
+	for _, other := range others {
+		if CosineSimilarity(v, other) >= tr.Threshold {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package clickableai
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRankKeepsATopic(t *testing.T) {
+	tr := NewTopicRanker(LexicalEmbedder{})
+	topics := []Topic{{Name: "Go channels", Confidence: 0.4}, {Name: "Go channels", Confidence: 0.9}}
+	ranked, err := tr.Rank(context.Background(), topics, []string{"Go channels"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranked) != 1 || ranked[0].Confidence != 0.9 {
+		t.Errorf("ranked = %+v, want only the most confident topic", ranked)
+	}
+}
+
+func TestRankTruncatesRunes(t *testing.T) {
+	var texts []string
+	tr := NewTopicRanker(embedderFunc(func(ctx context.Context, batch []string) ([][]float64, error) {
+		texts = batch
+		return LexicalEmbedder{}.Embed(ctx, batch)
+	}))
+	tr.MaxPage = 5
+	if _, err := tr.Rank(context.Background(), []Topic{{Name: "Æ"}, {Name: "Ø"}}, nil, strings.Repeat("æ", 10)); err != nil {
+		t.Fatal(err)
+	}
+	if page := texts[len(texts)-1]; page != "ææ" || !utf8.ValidString(page) {
+		t.Errorf("the page was truncated to %q", page)
+	}
+}